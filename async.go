@@ -0,0 +1,143 @@
+package choice4go
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// asyncRequest 收集一次性异步请求(如cst)的全部分段应答
+type asyncRequest struct {
+	token uintptr
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock     sync.Mutex
+	queue    []*EQMsg
+	finished bool
+	notify   chan struct{}
+}
+
+func newAsyncRequest(ctx context.Context) *asyncRequest {
+	req := &asyncRequest{
+		notify: make(chan struct{}, 1),
+	}
+
+	req.ctx, req.cancel = context.WithCancel(ctx)
+	req.token = msgDispatcher.register(req)
+
+	return req
+}
+
+func (req *asyncRequest) onMessage(msg *EQMsg) {
+	req.lock.Lock()
+	defer req.lock.Unlock()
+
+	if req.finished {
+		return
+	}
+
+	req.queue = append(req.queue, msg)
+
+	// 收到错误或最终应答时请求结束, 之后的消息丢弃
+	req.finished = msg.Err != nil || msg.MsgType == MsgTypeRsp
+
+	select {
+	case req.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (req *asyncRequest) close() {
+	req.cancel()
+	msgDispatcher.unregister(req.token)
+}
+
+// messages 按到达顺序返回应答消息, 直至请求结束或ctx取消
+func (req *asyncRequest) messages() func(yield func(*EQMsg, error) bool) {
+	return func(yield func(*EQMsg, error) bool) {
+		defer req.close()
+
+		for {
+			select {
+			case <-req.ctx.Done():
+				yield(nil, req.ctx.Err())
+				return
+			case <-req.notify:
+			}
+
+			req.lock.Lock()
+			queue, finished := req.queue, req.finished
+			req.queue = nil
+			req.lock.Unlock()
+
+			for _, msg := range queue {
+				if !yield(msg, nil) {
+					return
+				}
+			}
+
+			if finished {
+				return
+			}
+		}
+	}
+}
+
+// CstIter 以流式迭代器返回日内跳价数据, 每个分段应答产生一个EQData
+func (ins *Choice) CstIter(
+	ctx context.Context,
+	codes, indicators []string,
+	start, end time.Time,
+	options Option,
+) func(yield func(*EQData, error) bool) {
+	return func(yield func(*EQData, error) bool) {
+		req, err := ins.startCst(ctx, codes, indicators, start, end, options)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for msg, err := range req.messages() {
+			if err == nil {
+				err = msg.Err
+			}
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if msg.Data == nil {
+				continue
+			}
+
+			if !yield(msg.Data, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Cst 阻塞等待日内跳价请求完成, 并将全部分段应答合并为一个EQData
+func (ins *Choice) Cst(
+	ctx context.Context,
+	codes, indicators []string,
+	start, end time.Time,
+	options Option,
+) (*EQData, error) {
+	var parts []*EQData
+
+	for data, err := range ins.CstIter(
+		ctx, codes, indicators, start, end, options,
+	) {
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, data)
+	}
+
+	return mergeEQData(parts...)
+}
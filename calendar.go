@@ -0,0 +1,267 @@
+package choice4go
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// 跨年查找交易日时最多连续加载的空年份数, 超出后回退至服务端查询
+	maxCalendarEmptyYears = 2
+)
+
+// parseTradeDays 解析tradedates/gettradedate返回的交易日列表
+func parseTradeDays(data *EQData) []time.Time {
+	results := make([]time.Time, 0, len(data.values))
+
+	for _, v := range data.values {
+		if v.GetType() != ValueString {
+			continue
+		}
+
+		if date, err := parseEQDate(v.GetString()); err == nil {
+			results = append(results, date)
+		}
+	}
+
+	if len(results) == 0 {
+		for _, dateStr := range data.dateList {
+			if date, err := parseEQDate(dateStr); err == nil {
+				results = append(results, date)
+			}
+		}
+	}
+
+	slices.SortFunc(results, time.Time.Compare)
+
+	return slices.CompactFunc(results, time.Time.Equal)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// TradingCalendar 按市场缓存的交易日历, 以自然年为单位从服务端加载
+type TradingCalendar struct {
+	ins    *Choice
+	market string
+
+	lock  sync.Mutex
+	years map[int][]time.Time
+}
+
+// TradingCalendar 获取指定市场的交易日历, market为空时使用服务端默认市场
+func (ins *Choice) TradingCalendar(market string) *TradingCalendar {
+	calendar, _ := ins.calendars.LoadOrStore(market, &TradingCalendar{
+		ins:    ins,
+		market: market,
+		years:  make(map[int][]time.Time),
+	})
+
+	return calendar.(*TradingCalendar)
+}
+
+func (cal *TradingCalendar) options() Option {
+	opt := NewTradeDatesOptions()
+
+	if cal.market != "" {
+		opt.Market(cal.market)
+	}
+
+	return opt
+}
+
+func (cal *TradingCalendar) year(year int) ([]time.Time, error) {
+	cal.lock.Lock()
+	defer cal.lock.Unlock()
+
+	if days, exist := cal.years[year]; exist {
+		return days, nil
+	}
+
+	data, err := cal.ins.TradeDates(
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local),
+		time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local),
+		cal.options(),
+	)
+
+	var days []time.Time
+	switch {
+	case err == nil:
+		days = parseTradeDays(data)
+	case errors.Is(err, ErrDataEmpty):
+	default:
+		return nil, err
+	}
+
+	cal.years[year] = days
+
+	return days, nil
+}
+
+// Invalidate 清除已缓存的交易日数据
+func (cal *TradingCalendar) Invalidate() {
+	cal.lock.Lock()
+	defer cal.lock.Unlock()
+
+	clear(cal.years)
+}
+
+func (cal *TradingCalendar) IsTradingDay(t time.Time) (bool, error) {
+	t = truncateDay(t)
+
+	days, err := cal.year(t.Year())
+	if err != nil {
+		return false, err
+	}
+
+	_, found := slices.BinarySearchFunc(days, t, time.Time.Compare)
+
+	return found, nil
+}
+
+// Next 返回t之后(不含t)的第一个交易日
+func (cal *TradingCalendar) Next(t time.Time) (time.Time, error) {
+	return cal.Offset(t, 1)
+}
+
+// Prev 返回t之前(不含t)的最后一个交易日
+func (cal *TradingCalendar) Prev(t time.Time) (time.Time, error) {
+	return cal.Offset(t, -1)
+}
+
+// Offset 返回t偏移n个交易日的日期
+//
+// n > 0 时向后偏移, n < 0 时向前偏移, 均不含t本身;
+// n == 0 时t为交易日则返回t, 否则返回t之前的最后一个交易日.
+func (cal *TradingCalendar) Offset(t time.Time, n int) (time.Time, error) {
+	t = truncateDay(t)
+	year := t.Year()
+
+	days, err := cal.year(year)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	idx, found := slices.BinarySearchFunc(days, t, time.Time.Compare)
+
+	// 统一转换为相对于 days[idx] 的偏移
+	switch {
+	case n > 0:
+		if found {
+			idx += n
+		} else {
+			idx += n - 1
+		}
+	case n < 0:
+		idx += n
+	default:
+		if !found {
+			idx--
+		}
+	}
+
+	emptyYears := 0
+	for idx < 0 || idx >= len(days) {
+		if emptyYears > maxCalendarEmptyYears {
+			return cal.ins.GetTradeDate(t, n, cal.options())
+		}
+
+		if idx < 0 {
+			year--
+
+			prev, err := cal.year(year)
+			if err != nil {
+				return time.Time{}, err
+			}
+
+			if len(prev) == 0 {
+				emptyYears++
+			}
+
+			idx += len(prev)
+			days = prev
+		} else {
+			idx -= len(days)
+			year++
+
+			next, err := cal.year(year)
+			if err != nil {
+				return time.Time{}, err
+			}
+
+			if len(next) == 0 {
+				emptyYears++
+			}
+
+			days = next
+		}
+	}
+
+	return days[idx], nil
+}
+
+// Range 返回[start, end]区间内的全部交易日
+func (cal *TradingCalendar) Range(start, end time.Time) ([]time.Time, error) {
+	start, end = truncateDay(start), truncateDay(end)
+
+	if end.Before(start) {
+		return nil, fmt.Errorf(
+			"%w: end date before start date", ErrInvalidArgs,
+		)
+	}
+
+	var results []time.Time
+
+	for year := start.Year(); year <= end.Year(); year++ {
+		days, err := cal.year(year)
+		if err != nil {
+			return nil, err
+		}
+
+		from, _ := slices.BinarySearchFunc(days, start, time.Time.Compare)
+		to, found := slices.BinarySearchFunc(days, end, time.Time.Compare)
+		if found {
+			to++
+		}
+
+		results = append(results, days[from:to]...)
+	}
+
+	return results, nil
+}
+
+// Count 返回[start, end]区间内的交易日天数, 区间未缓存时由服务端计算
+func (cal *TradingCalendar) Count(start, end time.Time) (int, error) {
+	start, end = truncateDay(start), truncateDay(end)
+
+	if end.Before(start) {
+		return 0, fmt.Errorf(
+			"%w: end date before start date", ErrInvalidArgs,
+		)
+	}
+
+	cal.lock.Lock()
+	cached := true
+	for year := start.Year(); year <= end.Year(); year++ {
+		if _, exist := cal.years[year]; !exist {
+			cached = false
+			break
+		}
+	}
+	cal.lock.Unlock()
+
+	if !cached {
+		return cal.ins.TradeDatesNum(start, end, cal.options())
+	}
+
+	days, err := cal.Range(start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(days), nil
+}
@@ -0,0 +1,63 @@
+package choice4go
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTradingCalendarOffset(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	cal := &TradingCalendar{
+		years: map[int][]time.Time{
+			2023: {day(2023, 12, 28), day(2023, 12, 29)},
+			2024: {day(2024, 1, 2), day(2024, 1, 3), day(2024, 1, 5)},
+			2025: {day(2025, 1, 2)},
+		},
+	}
+
+	cases := []struct {
+		from     time.Time
+		n        int
+		expected time.Time
+	}{
+		{day(2024, 1, 3), 1, day(2024, 1, 5)},
+		{day(2024, 1, 4), 1, day(2024, 1, 5)},
+		{day(2024, 1, 4), -1, day(2024, 1, 3)},
+		{day(2024, 1, 4), 0, day(2024, 1, 3)},
+		{day(2024, 1, 3), 0, day(2024, 1, 3)},
+		{day(2024, 1, 2), -2, day(2023, 12, 28)},
+		{day(2024, 1, 1), 1, day(2024, 1, 2)},
+		{day(2024, 1, 5), 1, day(2025, 1, 2)},
+	}
+
+	for _, c := range cases {
+		result, err := cal.Offset(c.from, c.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !result.Equal(c.expected) {
+			t.Errorf(
+				"offset %s by %d: expected %s, got %s",
+				c.from.Format(time.DateOnly), c.n,
+				c.expected.Format(time.DateOnly), result.Format(time.DateOnly),
+			)
+		}
+	}
+
+	days, err := cal.Range(day(2023, 12, 29), day(2024, 1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(days) != 3 {
+		t.Errorf("unexpected range: %v", days)
+	}
+
+	if ok, _ := cal.IsTradingDay(day(2024, 1, 4).Add(time.Hour)); ok {
+		t.Error("2024-01-04 should not be trading day")
+	}
+}
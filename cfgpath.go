@@ -0,0 +1,69 @@
+package choice4go
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	serverListFile = "ServerList.json.e"
+	userInfoFile   = "userInfo"
+)
+
+// checkCfgDir 校验配置目录存在且可写, SDK会在该目录下读写ServerList.json.e及userInfo
+func checkCfgDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("%w: cfg path %q: %w", ErrCfgPath, dir, err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%w: cfg path %q is not a directory", ErrCfgPath, dir)
+	}
+
+	probe, err := os.CreateTemp(dir, ".choice4go-*")
+	if err != nil {
+		return fmt.Errorf("%w: cfg path %q not writable: %w", ErrCfgPath, dir, err)
+	}
+
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return nil
+}
+
+// checkServerList 校验服务器配置文件ServerList.json.e是否存在
+func checkServerList(dir string) error {
+	serverList := filepath.Join(dir, serverListFile)
+
+	if _, err := os.Stat(serverList); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf(
+				"%w: server list %q not found", ErrCfgPath, serverList,
+			)
+		}
+
+		return fmt.Errorf("%w: %w", ErrCfgPath, err)
+	}
+
+	return nil
+}
+
+// checkTokenFile 校验登录所需的令牌文件userInfo是否存在
+func checkTokenFile(dir string) error {
+	token := filepath.Join(dir, userInfoFile)
+
+	if _, err := os.Stat(token); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf(
+				"%w: %w: %q not found", ErrTokenMissing, ErrNeedActivate, token,
+			)
+		}
+
+		return fmt.Errorf("%w: %w", ErrTokenMissing, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package choice4go
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckServerList(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := checkServerList(dir); !errors.Is(err, ErrCfgPath) {
+		t.Fatalf("expected ErrCfgPath for missing server list, got %v", err)
+	}
+
+	if err := os.WriteFile(
+		filepath.Join(dir, serverListFile), []byte{}, 0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := checkServerList(dir); err != nil {
+		t.Fatalf("server list exists but check failed: %v", err)
+	}
+}
@@ -47,6 +47,12 @@ const char* SET_PROXY_NAME = "setproxy";
 *  pfnCallback：日志回调函数*/
 const char* STARTER_NAME = "start";
 
+/**人工激活 适用于无界面运行环境（如远程linux）或无法运行LoginActivator程序的情况，激活成功后将获得的激活文件"userInfo"放到"ServerList.json.e"同级目录，再调用start登录
+*  参数说明：
+*  pLoginInfo：账户名密码结构体指针（必传项）   options:可传邮箱,人工激活后会将令牌文件"userInfo"发送至您传入的邮箱 例如："email=who@what.com"
+*  pfnCallback：日志回调函数 */
+const char* MANUAL_ACTIVATE_NAME = "manualactivate";
+
 //退出(结束退出时调用，只需调用一次)
 const char* STOPPER_NAME = "stop";
 
@@ -65,9 +71,55 @@ const char* TRADEDATE_QUERIER_NAME = "tradedates";
 //获取系统板块成分(同步请求)
 const char* SECTOR_QUERIER_NAME = "sector";
 
+//获取偏移N的交易日(同步请求)
+#if defined(__linux__)
+const char* GET_TRADE_DATE_NAME = "gettradedate";
+#else
+const char* GET_TRADE_DATE_NAME = "getdate";
+#endif
+
+//获取区间日期内的交易日天数(同步请求)
+const char* TRADEDATE_NUM_QUERIER_NAME = "tradedatesnum";
+
 //获取专题报表(同步请求)
 const char* CTR_QUERIER_NAME = "ctr";
 
+//分钟K线(同步请求) //code只支持单个股票/期货/指数
+const char* CSC_QUERIER_NAME = "csc";
+
+//历史分时(同步请求) //code只支持单个股票/期货/指数
+const char* CMC_QUERIER_NAME = "cmc";
+
+//专项历史分时(同步请求) //code只支持单个股票/期货/指数
+const char* CHMC_QUERIER_NAME = "chmc";
+
+//行情快照(同步请求) 每次indicators最多为64个
+const char* CSQ_SNAPSHOT_QUERIER_NAME = "csqsnapshot";
+
+//专项快照(同步请求)
+const char* CHQ_SNAPSHOT_QUERIER_NAME = "chqsnapshot";
+
+//条件选股(同步请求)
+const char* CPS_QUERIER_NAME = "cps";
+
+//组合账户关系查询(同步请求)
+const char* PQUERY_QUERIER_NAME = "pquery";
+
+//批量下单(同步请求)  pOrderInfo:下单信息指针数组  orderInfoSize：数组元素个数  combinCode：组合代码  remark：组合说明
+const char* PORDER_EXECUTOR_NAME = "porder";
+
+//新建组合(同步请求) combinCode:组合代码  combinName：组合名称  initialFound：初始资金（最大99999999999）  remark：组合说明
+const char* PCREATE_EXECUTOR_NAME = "pcreate";
+
+//删除组合(同步请求) combinCode:组合代码
+const char* PDELETE_EXECUTOR_NAME = "pdelete";
+
+//组合报表查询(同步请求) combinCode:组合代码  indicator:报表名称
+const char* PREPORT_QUERIER_NAME = "preport";
+
+//组合资金调配(同步请求) combinCode:组合代码  transferdirect:IN 增加资金 OUT 减少资金  date：调配日期  opCash：增加或减少的资金量 remark：操作说明
+const char* PCTRANSFER_EXECUTOR_NAME = "pctransfer";
+
 //仅供本API中同步接口返回数据指针释放内存(EQDATA* 或 EQCTRDATA* 或者 EQCHAR*, 不可传入其他指针，异步函数回调中的指针也不可传入)
 const char* DATA_RELEASER_NAME = "releasedata";
 
@@ -83,17 +135,51 @@ const char* CFN_QUERIER_NAME = "cfn";
 //板块树查询（同步请求）
 const char* CFN_DTL_QUERIER_NAME = "cfnquery";
 
+//实时行情(异步)  每次indicators最多为64个 options: Pushtype=0 增量推送  1全量推送  2增量推送2(证券增量，指标值全量)
+const char* CSQ_SUBSCRIBER_NAME = "csq";
+
+//取消实时行情订阅   serialID为0时 取消所有订阅
+const char* CSQ_CANCELER_NAME = "csqcancel";
+
+//资讯订阅函数(异步)  codes：东财代码或板块代码（不可混合） content：订阅内容
+const char* CNQ_SUBSCRIBER_NAME = "cnq";
+
+//资讯取消订阅  serialID为0时 取消所有订阅
+const char* CNQ_CANCELER_NAME = "cnqcancel";
+
+//专项订阅(异步)  options: Pushtype=0 增量推送  1全量推送  2增量推送2(证券增量，指标值全量)
+const char* CHQ_SUBSCRIBER_NAME = "chq";
+
+//取消专项订阅   serialID为0时 取消所有订阅
+const char* CHQ_CANCELER_NAME = "chqcancel";
+
+//日内跳价服务(异步)  startdatetime和enddatetime格式(YYYYMMDDHHMMSS或HHMMSS表示系统日期当天的时间，两者需使用同一种格式)
+const char* CST_QUERIER_NAME = "cst";
+
 typedef EQErr (*callback_setter)(datacallback);
 typedef const char* (*err_getter)(EQErr, EQLang);
 typedef EQErr (*starter)(EQLOGININFO*, const char*, logcallback);
 typedef EQErr (*stopper)();
+typedef void (*dir_setter)(const char*);
+typedef EQErr (*proxy_setter)(ProxyType, const char*, unsigned short, bool, const char*, const char*);
 typedef EQErr (*data_releaser)(void*);
 typedef EQErr (*query_pchar_pdata)(const char*, EQDATA**);
 typedef EQErr (*query_cfn_pdata)(const char*, const char*, eCfnMode, const char*, EQDATA**);
+typedef EQErr (*query_pchar_int_pdata)(const char*, int, const char*, EQDATA**);
+typedef EQErr (*query_pchar3_pint)(const char*, const char*, const char*, int*);
 typedef EQErr (*query_pchar2_pdata)(const char*, const char*, EQDATA**);
 typedef EQErr (*query_pchar3_pdata)(const char*, const char*, const char*, EQDATA**);
+typedef EQErr (*query_pchar4_pdata)(const char*, const char*, const char*, const char*, EQDATA**);
 typedef EQErr (*query_pchar5_pdata)(const char*, const char*, const char*, const char*, const char*, EQDATA**);
+typedef EQErr (*query_pchar2_pctrdata)(const char*, const char*, EQCTRDATA**);
 typedef EQErr (*query_pchar3_pctrdata)(const char*, const char*, const char*, EQCTRDATA**);
+typedef EQErr (*exec_pchar2)(const char*, const char*);
+typedef EQErr (*order_executor)(ORDERINFO*, int, const char*, const char*, const char*);
+typedef EQErr (*portfolio_creator)(const char*, const char*, int64_t, const char*, const char*);
+typedef EQErr (*cash_transfer)(const char*, const char*, const char*, double, const char*, const char*);
+typedef EQID (*async_pchar3)(const char*, const char*, const char*, datacallback, LPVOID, EQErr*);
+typedef EQID (*async_pchar5)(const char*, const char*, const char*, const char*, const char*, datacallback, LPVOID, EQErr*);
+typedef EQErr (*async_canceler)(EQID);
 
 int CallCbSetter(callback_setter fn, datacallback cb)
 {
@@ -112,6 +198,16 @@ int CallStarter(starter fn, EQLOGININFO* login, const char* options, logcallback
 
 int CallStopper(stopper fn) { return fn(); }
 
+void CallDirSetter(dir_setter fn, const char* dir) { fn(dir); }
+
+int CallProxySetter(
+	proxy_setter fn, ProxyType type, const char* host, unsigned short port,
+	bool verify, const char* user, const char* password
+)
+{
+	return fn(type, host, port, verify, user, password);
+}
+
 int CallDataReleaser(data_releaser fn, void* data) { return fn(data); }
 
 int CallPCharPData(
@@ -129,6 +225,22 @@ int CallCfnQuerier(
 	return fn(codes, content, mode, options, data);
 }
 
+int CallPCharIntPData(
+	query_pchar_int_pdata fn, const char* p1, int p2,
+	const char* p3, EQDATA** data
+)
+{
+	return fn(p1, p2, p3, data);
+}
+
+int CallPChar3PInt(
+	query_pchar3_pint fn, const char* p1, const char* p2,
+	const char* p3, int* num
+)
+{
+	return fn(p1, p2, p3, num);
+}
+
 int CallPChar2PData(
 	query_pchar2_pdata fn, const char* p1, const char* p2, EQDATA** data
 )
@@ -144,6 +256,14 @@ int CallPChar3PData(
 	return fn(p1, p2, p3, data);
 }
 
+int CallPChar4PData(
+	query_pchar4_pdata fn, const char* p1, const char* p2,
+	const char* p3, const char* p4, EQDATA** data
+)
+{
+	return fn(p1, p2, p3, p4, data);
+}
+
 int CallPChar5PData(
 	query_pchar5_pdata fn, const char* p1, const char* p2,
 	const char* p3, const char* p4, const char* p5, EQDATA** data
@@ -152,6 +272,13 @@ int CallPChar5PData(
 	return fn(p1, p2, p3, p4, p5, data);
 }
 
+int CallPChar2PCtrData(
+	query_pchar2_pctrdata fn, const char* p1, const char* p2, EQCTRDATA** data
+)
+{
+	return fn(p1, p2, data);
+}
+
 int CallPChar3PCtrData(
 	query_pchar3_pctrdata fn, const char* p1, const char* p2,
 	const char* p3, EQCTRDATA** data
@@ -160,6 +287,56 @@ int CallPChar3PCtrData(
 	return fn(p1, p2, p3, data);
 }
 
+int CallPChar2Exec(exec_pchar2 fn, const char* p1, const char* p2)
+{
+	return fn(p1, p2);
+}
+
+int CallOrderExecutor(
+	order_executor fn, ORDERINFO* orders, int size,
+	const char* code, const char* remark, const char* options
+)
+{
+	return fn(orders, size, code, remark, options);
+}
+
+int CallPortfolioCreator(
+	portfolio_creator fn, const char* code, const char* name,
+	int64_t initialFund, const char* remark, const char* options
+)
+{
+	return fn(code, name, initialFund, remark, options);
+}
+
+int CallCashTransfer(
+	cash_transfer fn, const char* code, const char* direction,
+	const char* date, double cash, const char* remark, const char* options
+)
+{
+	return fn(code, direction, date, cash, remark, options);
+}
+
+EQID CallPChar3Async(
+	async_pchar3 fn, const char* p1, const char* p2,
+	const char* p3, datacallback cb, uintptr_t param, EQErr* err
+)
+{
+	return fn(p1, p2, p3, cb, (LPVOID)param, err);
+}
+
+EQID CallPChar5Async(
+	async_pchar5 fn, const char* p1, const char* p2, const char* p3,
+	const char* p4, const char* p5, datacallback cb, uintptr_t param, EQErr* err
+)
+{
+	return fn(p1, p2, p3, p4, p5, cb, (LPVOID)param, err);
+}
+
+int CallAsyncCanceler(async_canceler fn, EQID serialID)
+{
+	return fn(serialID);
+}
+
 #ifdef __cplusplus
 }
 #endif
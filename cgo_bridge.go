@@ -22,8 +22,41 @@ func cgoLogCallback(log *C.char) C.int {
 	return 0
 }
 
+func newEQMsg(msg *C.EQMSG) *EQMsg {
+	result := EQMsg{
+		Version:   int(msg.version),
+		MsgType:   eqMsgType(msg.msgType),
+		RequestID: int(msg.requestID),
+		SerialID:  int(msg.serialID),
+		ErrCode:   int(msg.err),
+	}
+
+	if ins := singleton.Load(); ins != nil {
+		result.Err = ins.checkError(msg.err)
+	}
+
+	// 回调中的数据指针由SDK管理, 转换时已完成拷贝, 不可调用releasedata
+	if msg.pEQData != nil && msg.pEQData.valueArray.nSize > 0 {
+		data, err := newEQData(msg.pEQData)
+		if err != nil {
+			slog.Error(
+				"choice convert async data failed",
+				slog.Any("error", err),
+				slog.Int("serial_id", result.SerialID),
+			)
+		}
+		result.Data = data
+	}
+
+	return &result
+}
+
 //export cgoDataCallback
-func cgoDataCallback(msg *C.EQMSG, _param C.LPVOID) C.int {
+func cgoDataCallback(msg *C.EQMSG, param C.LPVOID) C.int {
+	if msgDispatcher.dispatch(uintptr(param), newEQMsg(msg)) {
+		return 0
+	}
+
 	version := int(msg.version)
 
 	switch msg.msgType {
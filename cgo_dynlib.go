@@ -18,7 +18,6 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"reflect"
 	"runtime"
 	"strings"
 	"sync"
@@ -42,9 +41,10 @@ type Choice struct {
 
 	loadOnce   sync.Once
 	unloadOnce sync.Once
-	startOnce  sync.Once
-	started    atomic.Bool
-	stopOnce   sync.Once
+	// lifecycleLock 串行化Start、Stop及relogin, stateLock仅保护rootCtx的读写
+	lifecycleLock sync.Mutex
+	stateLock     sync.RWMutex
+	state         atomic.Uint32
 
 	rootCtx    context.Context
 	rootCancel context.CancelFunc
@@ -63,6 +63,38 @@ type Choice struct {
 	edbDtlFn       C.query_pchar3_pdata
 	cfnFn          C.query_cfn_pdata
 	cfnDtlFn       C.query_pchar_pdata
+	csqFn          C.async_pchar3
+	csqCancelFn    C.async_canceler
+	cstFn          C.async_pchar5
+	cscFn          C.query_pchar5_pdata
+	cmcFn          C.query_pchar5_pdata
+	chmcFn         C.query_pchar5_pdata
+	csqSnapshotFn  C.query_pchar3_pdata
+	chqSnapshotFn  C.query_pchar3_pdata
+	cnqFn          C.async_pchar3
+	cnqCancelFn    C.async_canceler
+	chqFn          C.async_pchar3
+	chqCancelFn    C.async_canceler
+	cpsFn          C.query_pchar4_pdata
+	pqueryFn       C.query_pchar_pdata
+	porderFn       C.order_executor
+	pcreateFn      C.portfolio_creator
+	pdeleteFn      C.exec_pchar2
+	preportFn      C.query_pchar3_pdata
+	pctransferFn   C.cash_transfer
+	getTradeDateFn C.query_pchar_int_pdata
+	tradedateNumFn C.query_pchar3_pint
+	cfcFn          C.query_pchar3_pctrdata
+	cecFn          C.query_pchar2_pctrdata
+	proxySetterFn  C.proxy_setter
+	serverDirFn    C.dir_setter
+	activateFn     C.starter
+
+	calendars     sync.Map
+	subscriptions sync.Map
+	retry         atomic.Pointer[RetryPolicy]
+	limiters      sync.Map
+	quota         quotaMeter
 }
 
 func loadFuncErr() error {
@@ -73,6 +105,19 @@ func loadFuncErr() error {
 	)
 }
 
+// loadOptionalFn 加载可选的SDK导出函数, 旧版本SDK缺少该导出时返回nil
+func (ins *Choice) loadOptionalFn(name *C.char) unsafe.Pointer {
+	fn := C.dlsym(ins.lib, name)
+	if fn == nil {
+		slog.Warn(
+			"choice optional function not found",
+			slog.String("func", C.GoString(name)),
+		)
+	}
+
+	return fn
+}
+
 func NewChoice(libDir, libName, cfgPath string) (ins *Choice, err error) {
 	if ins = singleton.Load(); ins != nil {
 		return
@@ -229,11 +274,37 @@ func NewChoice(libDir, libName, cfgPath string) (ins *Choice, err error) {
 		} else {
 			ins.cfnDtlFn = (C.query_pchar_pdata)(fn)
 		}
+
+		// 以下为新版SDK导出的函数, 缺失时不影响加载, 调用时由checkLibFn返回未加载错误
+		ins.csqFn = (C.async_pchar3)(ins.loadOptionalFn(C.CSQ_SUBSCRIBER_NAME))
+		ins.csqCancelFn = (C.async_canceler)(ins.loadOptionalFn(C.CSQ_CANCELER_NAME))
+		ins.cstFn = (C.async_pchar5)(ins.loadOptionalFn(C.CST_QUERIER_NAME))
+		ins.cscFn = (C.query_pchar5_pdata)(ins.loadOptionalFn(C.CSC_QUERIER_NAME))
+		ins.cmcFn = (C.query_pchar5_pdata)(ins.loadOptionalFn(C.CMC_QUERIER_NAME))
+		ins.chmcFn = (C.query_pchar5_pdata)(ins.loadOptionalFn(C.CHMC_QUERIER_NAME))
+		ins.csqSnapshotFn = (C.query_pchar3_pdata)(ins.loadOptionalFn(C.CSQ_SNAPSHOT_QUERIER_NAME))
+		ins.chqSnapshotFn = (C.query_pchar3_pdata)(ins.loadOptionalFn(C.CHQ_SNAPSHOT_QUERIER_NAME))
+		ins.cnqFn = (C.async_pchar3)(ins.loadOptionalFn(C.CNQ_SUBSCRIBER_NAME))
+		ins.cnqCancelFn = (C.async_canceler)(ins.loadOptionalFn(C.CNQ_CANCELER_NAME))
+		ins.chqFn = (C.async_pchar3)(ins.loadOptionalFn(C.CHQ_SUBSCRIBER_NAME))
+		ins.chqCancelFn = (C.async_canceler)(ins.loadOptionalFn(C.CHQ_CANCELER_NAME))
+		ins.cpsFn = (C.query_pchar4_pdata)(ins.loadOptionalFn(C.CPS_QUERIER_NAME))
+		ins.pqueryFn = (C.query_pchar_pdata)(ins.loadOptionalFn(C.PQUERY_QUERIER_NAME))
+		ins.porderFn = (C.order_executor)(ins.loadOptionalFn(C.PORDER_EXECUTOR_NAME))
+		ins.pcreateFn = (C.portfolio_creator)(ins.loadOptionalFn(C.PCREATE_EXECUTOR_NAME))
+		ins.pdeleteFn = (C.exec_pchar2)(ins.loadOptionalFn(C.PDELETE_EXECUTOR_NAME))
+		ins.preportFn = (C.query_pchar3_pdata)(ins.loadOptionalFn(C.PREPORT_QUERIER_NAME))
+		ins.pctransferFn = (C.cash_transfer)(ins.loadOptionalFn(C.PCTRANSFER_EXECUTOR_NAME))
+		ins.getTradeDateFn = (C.query_pchar_int_pdata)(ins.loadOptionalFn(C.GET_TRADE_DATE_NAME))
+		ins.tradedateNumFn = (C.query_pchar3_pint)(ins.loadOptionalFn(C.TRADEDATE_NUM_QUERIER_NAME))
+		ins.cfcFn = (C.query_pchar3_pctrdata)(ins.loadOptionalFn(C.CFC_VERIFIER_NAME))
+		ins.cecFn = (C.query_pchar2_pctrdata)(ins.loadOptionalFn(C.CEC_VERIFIER_NAME))
+		ins.proxySetterFn = (C.proxy_setter)(ins.loadOptionalFn(C.SET_PROXY_NAME))
+		ins.serverDirFn = (C.dir_setter)(ins.loadOptionalFn(C.SET_SERVER_LIST_NAME))
+		ins.activateFn = (C.starter)(ins.loadOptionalFn(C.MANUAL_ACTIVATE_NAME))
 	})
 
 	runtime.SetFinalizer(ins, func(ins *Choice) {
-		ins.rootCancel()
-
 		ins.unloadOnce.Do(func() {
 			// 清理全局单例指针
 			singleton.CompareAndSwap(ins, nil)
@@ -261,6 +332,32 @@ func NewChoice(libDir, libName, cfgPath string) (ins *Choice, err error) {
 			ins.edbDtlFn = nil
 			ins.cfnFn = nil
 			ins.cfnDtlFn = nil
+			ins.csqFn = nil
+			ins.csqCancelFn = nil
+			ins.cstFn = nil
+			ins.cscFn = nil
+			ins.cmcFn = nil
+			ins.chmcFn = nil
+			ins.csqSnapshotFn = nil
+			ins.chqSnapshotFn = nil
+			ins.cnqFn = nil
+			ins.cnqCancelFn = nil
+			ins.chqFn = nil
+			ins.chqCancelFn = nil
+			ins.cpsFn = nil
+			ins.pqueryFn = nil
+			ins.porderFn = nil
+			ins.pcreateFn = nil
+			ins.pdeleteFn = nil
+			ins.preportFn = nil
+			ins.pctransferFn = nil
+			ins.getTradeDateFn = nil
+			ins.tradedateNumFn = nil
+			ins.cfcFn = nil
+			ins.cecFn = nil
+			ins.proxySetterFn = nil
+			ins.serverDirFn = nil
+			ins.activateFn = nil
 		})
 	})
 
@@ -283,14 +380,30 @@ func (ins *Choice) getErrString(code C.EQErr) string {
 	return C.GoString(msg)
 }
 
+// activateError 人工激活结果转换为error, 设备无需人工激活时视为成功
+func (ins *Choice) activateError(code int) error {
+	if EQErr(code) == EQERR_NOTNEED_MANUAL_ACTIVATE {
+		slog.Info("choice device already activated")
+		return nil
+	}
+
+	return ins.errorOf(code)
+}
+
+// errorOf 将回调消息中的错误码转换为error
+func (ins *Choice) errorOf(code int) error {
+	return ins.checkError(C.EQErr(code))
+}
+
 func (ins *Choice) checkError(code C.EQErr) error {
 	if code == 0 {
 		return nil
 	}
 
-	msg := ins.getErrString(code)
-
-	return fmt.Errorf("%w: [%d] %s", ErrEQCall, code, msg)
+	return &EQError{
+		Code: EQErr(code),
+		Msg:  ins.getErrString(code),
+	}
 }
 
 func (ins *Choice) checkLibFn(name string) (fn *[0]byte, err error) {
@@ -325,12 +438,68 @@ func (ins *Choice) checkLibFn(name string) (fn *[0]byte, err error) {
 		fn = ins.cfnFn
 	case "cfnquery":
 		fn = ins.cfnDtlFn
+	case "csq":
+		fn = ins.csqFn
+	case "csqcancel":
+		fn = ins.csqCancelFn
+	case "cst":
+		fn = ins.cstFn
+	case "csc":
+		fn = ins.cscFn
+	case "cmc":
+		fn = ins.cmcFn
+	case "chmc":
+		fn = ins.chmcFn
+	case "csqsnapshot":
+		fn = ins.csqSnapshotFn
+	case "chqsnapshot":
+		fn = ins.chqSnapshotFn
+	case "cnq":
+		fn = ins.cnqFn
+	case "cnqcancel":
+		fn = ins.cnqCancelFn
+	case "chq":
+		fn = ins.chqFn
+	case "chqcancel":
+		fn = ins.chqCancelFn
+	case "cps":
+		fn = ins.cpsFn
+	case "pquery":
+		fn = ins.pqueryFn
+	case "porder":
+		fn = ins.porderFn
+	case "pcreate":
+		fn = ins.pcreateFn
+	case "pdelete":
+		fn = ins.pdeleteFn
+	case "preport":
+		fn = ins.preportFn
+	case "pctransfer":
+		fn = ins.pctransferFn
+	case "gettradedate":
+		fn = ins.getTradeDateFn
+	case "tradedatesnum":
+		fn = ins.tradedateNumFn
+	case "cfc":
+		fn = ins.cfcFn
+	case "cec":
+		fn = ins.cecFn
+	case "setproxy":
+		fn = ins.proxySetterFn
+	case "setserverlistdir":
+		fn = ins.serverDirFn
+	case "manualactivate":
+		fn = ins.activateFn
 	default:
-		err = fmt.Errorf(
-			"%w: unkown data function call %s", ErrLoadFunc, name,
+		return nil, fmt.Errorf(
+			"%w: %w %s", ErrLoadFunc, ErrUnknownFunc, name,
 		)
 	}
 
+	if fn == nil {
+		err = fmt.Errorf("%w: %s not loaded", ErrLoadFunc, name)
+	}
+
 	return
 }
 
@@ -340,85 +509,169 @@ func (ins *Choice) releaseData(data *C.EQDATA) error {
 	))
 }
 
-func (ins *Choice) Start(
+func (ins *Choice) releaseCtrData(data *C.EQCTRDATA) error {
+	return ins.checkError(C.CallDataReleaser(
+		ins.dataReleaserFn, unsafe.Pointer(data),
+	))
+}
+
+// newLoginInfo 构造账户信息结构体, 由调用方负责释放
+func newLoginInfo(user, pass string) *C.EQLOGININFO {
+	login := (*C.EQLOGININFO)(C.calloc(1, C.size_t(unsafe.Sizeof(C.EQLOGININFO{}))))
+
+	cUser := C.CString(user)
+	cPass := C.CString(pass)
+	defer freeCStrings(cUser, cPass)
+
+	C.strncpy(&login.userName[0], cUser, C.size_t(len(login.userName)-1))
+	C.strncpy(&login.password[0], cPass, C.size_t(len(login.password)-1))
+
+	return login
+}
+
+// Activate 人工激活当前设备, 激活成功后令牌文件userInfo将写入cfgPath目录
+//
+// ctx结束时仅停止等待, 激活调用仍在后台执行至SDK返回, 期间其余Activate及Start将阻塞等待.
+func (ins *Choice) Activate(
 	ctx context.Context,
 	user, pass string,
 	options Option,
-) (err error) {
-	fn, err := ins.checkLibFn("start")
+) error {
+	fn, err := ins.checkLibFn("manualactivate")
 	if err != nil {
 		return err
 	}
 
-	if ctx == nil {
-		ctx = context.Background()
+	if ins.isStarted() {
+		return fmt.Errorf(
+			"%w: activate must be called before start", ErrAlreadyStarted,
+		)
 	}
 
-	ins.startOnce.Do(func() {
-		ins.rootCtx, ins.rootCancel = context.WithCancel(ctx)
-
-		cUser := C.CString(user)
-		cPass := C.CString(pass)
-
-		var cOptions *C.char
-		if options != nil {
-			cOptions = C.CString(options.OptionString())
-		}
+	if err := ins.applyCfgPath(false); err != nil {
+		return err
+	}
 
+	return ins.activate(ctx, func() error {
+		cOptions := optionCString(options)
+		login := newLoginInfo(user, pass)
 		defer func() {
-			C.free(unsafe.Pointer(cUser))
-			C.free(unsafe.Pointer(cPass))
-			C.free(unsafe.Pointer(cOptions))
+			C.free(unsafe.Pointer(login))
+			freeCStrings(cOptions)
 		}()
 
-		login := C.EQLOGININFO{}
-		C.memcpy(
-			unsafe.Pointer(&login.userName[0]),
-			unsafe.Pointer(cUser),
-			255,
-		)
-		C.memcpy(
-			unsafe.Pointer(&login.password[0]),
-			unsafe.Pointer(cPass),
-			255,
-		)
-
 		slog.Info(
-			"choice run start with options",
+			"choice run manual activate with options",
 			slog.String("user", user),
 			slog.Any("options", options),
 		)
-		err = ins.checkError(C.CallStarter(
-			fn, &login, cOptions,
-			C.logcallback(unsafe.Pointer(C.cLogCallback)),
-		))
 
-		ins.started.Store(err == nil)
+		return ins.activateError(int(C.CallStarter(
+			fn, login, cOptions,
+			C.logcallback(unsafe.Pointer(C.cLogCallback)),
+		)))
 	})
+}
 
-	return
+// applyCfgPath 设置ServerList.json.e及userInfo的存放目录, cfgPath为空时使用当前目录
+func (ins *Choice) applyCfgPath(requireToken bool) error {
+	if ins.cfgPath == "" {
+		return nil
+	}
+
+	fn, err := ins.checkLibFn("setserverlistdir")
+	if err != nil {
+		return err
+	}
+
+	if err := checkCfgDir(ins.cfgPath); err != nil {
+		return err
+	}
+
+	if err := checkServerList(ins.cfgPath); err != nil {
+		return err
+	}
+
+	if requireToken {
+		if err := checkTokenFile(ins.cfgPath); err != nil {
+			return err
+		}
+	}
+
+	cDir := C.CString(ins.cfgPath)
+	defer C.free(unsafe.Pointer(cDir))
+
+	slog.Info(
+		"choice set server list dir",
+		slog.String("cfg_path", ins.cfgPath),
+	)
+	C.CallDirSetter(C.dir_setter(fn), cDir)
+
+	return nil
+}
+
+// login 调用start登录, 不改变生命周期状态
+func (ins *Choice) login(user, pass string, options Option) error {
+	fn, err := ins.checkLibFn("start")
+	if err != nil {
+		return err
+	}
+
+	cOptions := optionCString(options)
+	defer freeCStrings(cOptions)
+
+	login := newLoginInfo(user, pass)
+	defer C.free(unsafe.Pointer(login))
+
+	slog.Info(
+		"choice run start with options",
+		slog.String("user", user),
+		slog.Any("options", options),
+	)
+	code := C.CallStarter(
+		fn, login, cOptions,
+		C.logcallback(unsafe.Pointer(C.cLogCallback)),
+	)
+
+	// 需激活的错误码可通过errors.Is(err, ErrNeedActivate)判断
+	return ins.checkError(code)
 }
 
-func (ins *Choice) Stop() (err error) {
+// logout 调用stop退出登录, 不取消rootCtx, 已有订阅保持不变
+func (ins *Choice) logout() error {
 	fn, err := ins.checkLibFn("stop")
 	if err != nil {
 		return err
 	}
 
-	if !ins.started.Load() {
-		slog.Warn("choice api not started")
-		return
+	return ins.checkError(C.CallStopper(fn))
+}
+
+func (ins *Choice) Start(
+	ctx context.Context,
+	user, pass string,
+	options Option,
+) error {
+	if _, err := ins.checkLibFn("start"); err != nil {
+		return err
 	}
 
-	ins.stopOnce.Do(func() {
-		ins.rootCancel()
+	return ins.start(ctx, func() error {
+		if err := ins.applyCfgPath(true); err != nil {
+			return err
+		}
 
-		err = ins.checkError(C.CallStopper(fn))
+		return ins.login(user, pass, options)
 	})
+}
 
-	ins.started.Store(false)
+// Stop 退出登录, 之后可再次调用Start重新登录
+func (ins *Choice) Stop() error {
+	if _, err := ins.checkLibFn("stop"); err != nil {
+		return err
+	}
 
-	return
+	return ins.stop(ins.logout)
 }
 
 func convertStringArr(arr C.EQCHARARRAY) []string {
@@ -426,11 +679,7 @@ func convertStringArr(arr C.EQCHARARRAY) []string {
 		return nil
 	}
 
-	strArr := *(*[]C.EQCHAR)(unsafe.Pointer(&reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(arr.pChArray)),
-		Len:  int(arr.nSize),
-		Cap:  int(arr.nSize),
-	}))
+	strArr := unsafe.Slice(arr.pChArray, int(arr.nSize))
 
 	results := make([]string, 0, arr.nSize)
 	for _, str := range strArr {
@@ -542,11 +791,7 @@ func newEQData(v *C.EQDATA) (*EQData, error) {
 	data.dateList = convertStringArr(v.dateArray)
 	data.values = make([]*EQValue, v.valueArray.nSize)
 
-	values := *(*[]C.EQVARIENT)(unsafe.Pointer(&reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(v.valueArray.pEQVarient)),
-		Len:  int(v.valueArray.nSize),
-		Cap:  int(v.valueArray.nSize),
-	}))
+	values := unsafe.Slice(v.valueArray.pEQVarient, int(v.valueArray.nSize))
 
 	var err error
 	for idx, v := range values {
@@ -555,13 +800,7 @@ func newEQData(v *C.EQDATA) (*EQData, error) {
 		}
 	}
 
-	runtime.SetFinalizer(data, func(data *EQData) {
-		for _, value := range data.values {
-			valuePool.Put(value)
-		}
-
-		dataPool.Put(data)
-	})
+	runtime.SetFinalizer(data, releaseEQData)
 
 	return data, nil
 }
@@ -591,11 +830,7 @@ func newEQCtrData(v *C.EQCTRDATA) (*EQCtrData, error) {
 	data.indicators = convertStringArr(v.indicatorArray)
 	data.values = make([]*EQValue, v.valueArray.nSize)
 
-	values := *(*[]C.EQVARIENT)(unsafe.Pointer(&reflect.SliceHeader{
-		Data: uintptr(unsafe.Pointer(v.valueArray.pEQVarient)),
-		Len:  int(v.valueArray.nSize),
-		Cap:  int(v.valueArray.nSize),
-	}))
+	values := unsafe.Slice(v.valueArray.pEQVarient, int(v.valueArray.nSize))
 
 	var err error
 	for idx, v := range values {
@@ -604,13 +839,7 @@ func newEQCtrData(v *C.EQCTRDATA) (*EQCtrData, error) {
 		}
 	}
 
-	runtime.SetFinalizer(data, func(data *EQCtrData) {
-		for _, value := range data.values {
-			valuePool.Put(value)
-		}
-
-		ctrDataPool.Put(data)
-	})
+	runtime.SetFinalizer(data, releaseEQCtrData)
 
 	return data, nil
 }
@@ -640,49 +869,172 @@ func checkCommonArgs(
 		cOptions, nil
 }
 
-func (ins *Choice) callPData(
-	fn *[0]byte, args ...*C.char,
+func freeCStrings(args ...*C.char) {
+	for _, ptr := range args {
+		C.free(unsafe.Pointer(ptr))
+	}
+}
+
+func (ins *Choice) queryPData(
+	ctx context.Context,
+	name string, call func(pData **C.EQDATA) C.EQErr,
 ) (*EQData, error) {
-	defer func() {
-		for _, ptr := range args {
-			C.free(unsafe.Pointer(ptr))
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	policy := ins.queryRetryPolicy(name)
+
+	for attempt := 1; ; attempt++ {
+		var data *EQData
+
+		err := ins.invoke(ctx, name, func() (int, error) {
+			var pData *C.EQDATA
+
+			if err := ins.checkError(call(&pData)); err != nil {
+				return 0, err
+			}
+			defer ins.releaseData(pData)
+
+			result, err := newEQData(pData)
+			data = result
+
+			return data.cells(), err
+		})
+		if err == nil {
+			return data, nil
 		}
-	}()
 
-	var (
-		pData *C.EQDATA
-		rtn   C.EQErr
-	)
+		if attempt >= policy.MaxAttempts || !retryable(err) {
+			return nil, err
+		}
+
+		wait := policy.backoff(attempt)
+
+		slog.Warn(
+			"choice query failed, retrying",
+			slog.Int("attempt", attempt),
+			slog.Int("max_attempts", policy.MaxAttempts),
+			slog.Duration("wait", wait),
+			slog.Any("error", err),
+		)
+
+		if !ins.waitRetry(ctx, wait) {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("%w: retry aborted: %w", ctxErr, err)
+			}
+
+			return nil, err
+		}
+	}
+}
+
+func (ins *Choice) callPData(
+	name string, fn *[0]byte, args ...*C.char,
+) (*EQData, error) {
+	return ins.callPDataContext(context.Background(), name, fn, args...)
+}
+
+// callPDataContext 同callPData, ctx结束时中止重试等待
+func (ins *Choice) callPDataContext(
+	ctx context.Context,
+	name string, fn *[0]byte, args ...*C.char,
+) (*EQData, error) {
+	defer freeCStrings(args...)
+
+	var call func(pData **C.EQDATA) C.EQErr
 
 	switch len(args) {
 	case 1:
-		rtn = C.CallPCharPData(fn, args[0], &pData)
+		call = func(pData **C.EQDATA) C.EQErr {
+			return C.CallPCharPData(fn, args[0], pData)
+		}
 	case 2:
-		rtn = C.CallPChar2PData(fn, args[0], args[1], &pData)
+		call = func(pData **C.EQDATA) C.EQErr {
+			return C.CallPChar2PData(fn, args[0], args[1], pData)
+		}
 	case 3:
-		rtn = C.CallPChar3PData(fn, args[0], args[1], args[2], &pData)
+		call = func(pData **C.EQDATA) C.EQErr {
+			return C.CallPChar3PData(fn, args[0], args[1], args[2], pData)
+		}
+	case 4:
+		call = func(pData **C.EQDATA) C.EQErr {
+			return C.CallPChar4PData(
+				fn, args[0], args[1], args[2], args[3], pData,
+			)
+		}
 	case 5:
-		rtn = C.CallPChar5PData(
-			fn, args[0], args[1], args[2], args[3], args[4], &pData,
-		)
+		call = func(pData **C.EQDATA) C.EQErr {
+			return C.CallPChar5PData(
+				fn, args[0], args[1], args[2], args[3], args[4], pData,
+			)
+		}
 	default:
 		return nil, fmt.Errorf(
 			"%w: unsupported args count: %d", ErrInvalidArgs, len(args),
 		)
 	}
 
-	if err := ins.checkError(rtn); err != nil {
-		return nil, err
+	return ins.queryPData(ctx, name, call)
+}
+
+func (ins *Choice) callPCtrData(
+	name string, fn *[0]byte, args ...*C.char,
+) (*EQCtrData, error) {
+	defer freeCStrings(args...)
+
+	var call func(pData **C.EQCTRDATA) C.EQErr
+
+	switch len(args) {
+	case 2:
+		call = func(pData **C.EQCTRDATA) C.EQErr {
+			return C.CallPChar2PCtrData(fn, args[0], args[1], pData)
+		}
+	case 3:
+		call = func(pData **C.EQCTRDATA) C.EQErr {
+			return C.CallPChar3PCtrData(fn, args[0], args[1], args[2], pData)
+		}
+	default:
+		return nil, fmt.Errorf(
+			"%w: unsupported args count: %d", ErrInvalidArgs, len(args),
+		)
 	}
-	defer ins.releaseData(pData)
 
-	return newEQData(pData)
+	var data *EQCtrData
+
+	err := ins.invoke(context.Background(), name, func() (int, error) {
+		var pData *C.EQCTRDATA
+
+		if err := ins.checkError(call(&pData)); err != nil {
+			return 0, err
+		}
+		defer ins.releaseCtrData(pData)
+
+		result, err := newEQCtrData(pData)
+		data = result
+
+		return data.cells(), err
+	})
+
+	return data, err
 }
 
 func (ins *Choice) Csd(
 	codes, indicators []string,
 	start, end time.Time,
 	options Option,
+) (*EQData, error) {
+	return ins.CsdContext(
+		context.Background(), codes, indicators, start, end, options,
+	)
+}
+
+// CsdContext 同Csd, ctx结束时中止限流及重试等待
+func (ins *Choice) CsdContext(
+	ctx context.Context,
+	codes, indicators []string,
+	start, end time.Time,
+	options Option,
 ) (*EQData, error) {
 	fn, err := ins.checkLibFn("csd")
 	if err != nil {
@@ -697,13 +1049,21 @@ func (ins *Choice) Csd(
 	cStart := C.CString(start.Format("2006-01-02"))
 	cEnd := C.CString(end.Format("2006-01-02"))
 
-	return ins.callPData(
+	return ins.callPDataContext(ctx, "csd",
 		fn, cCodes, cIndicators, cStart, cEnd, cOptions,
 	)
 }
 
 func (ins *Choice) Css(
 	codes, indicators []string, options Option,
+) (*EQData, error) {
+	return ins.CssContext(context.Background(), codes, indicators, options)
+}
+
+// CssContext 同Css, ctx结束时中止限流及重试等待
+func (ins *Choice) CssContext(
+	ctx context.Context,
+	codes, indicators []string, options Option,
 ) (*EQData, error) {
 	fn, err := ins.checkLibFn("css")
 	if err != nil {
@@ -715,7 +1075,7 @@ func (ins *Choice) Css(
 		return nil, err
 	}
 
-	return ins.callPData(
+	return ins.callPDataContext(ctx, "css",
 		fn, cCodes, cIndicators, cOptions,
 	)
 }
@@ -723,7 +1083,15 @@ func (ins *Choice) Css(
 func (ins *Choice) CSec(
 	blockCodes, indicators []string, options Option,
 ) (*EQData, error) {
-	fn, err := ins.checkLibFn("csec")
+	return ins.CSecContext(context.Background(), blockCodes, indicators, options)
+}
+
+// CSecContext 同CSec, ctx结束时中止限流及重试等待
+func (ins *Choice) CSecContext(
+	ctx context.Context,
+	blockCodes, indicators []string, options Option,
+) (*EQData, error) {
+	fn, err := ins.checkLibFn("cses")
 	if err != nil {
 		return nil, err
 	}
@@ -741,7 +1109,7 @@ func (ins *Choice) CSec(
 		return nil, err
 	}
 
-	return ins.callPData(
+	return ins.callPDataContext(ctx, "cses",
 		fn, cCodes, cIndicators, cOptions,
 	)
 }
@@ -762,11 +1130,828 @@ func (ins *Choice) TradeDates(
 	if options != nil {
 		cOptions = C.CString(options.OptionString())
 	}
-	defer func() {
-		C.free(unsafe.Pointer(cStart))
-		C.free(unsafe.Pointer(cEnd))
-		C.free(unsafe.Pointer(cOptions))
-	}()
 
-	return ins.callPData(fn, cStart, cEnd, cOptions)
+	return ins.callPData("tradedates", fn, cStart, cEnd, cOptions)
+}
+
+// GetTradeDate 获取指定日期偏移offset个交易日的日期
+func (ins *Choice) GetTradeDate(
+	date time.Time, offset int, options Option,
+) (time.Time, error) {
+	fn, err := ins.checkLibFn("gettradedate")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	cDate := C.CString(date.Format("2006-01-02"))
+	cOptions := optionCString(options)
+	defer freeCStrings(cDate, cOptions)
+
+	data, err := ins.queryPData(context.Background(), "gettradedate", func(pData **C.EQDATA) C.EQErr {
+		return C.CallPCharIntPData(fn, cDate, C.int(offset), cOptions, pData)
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if days := parseTradeDays(data); len(days) > 0 {
+		return days[0], nil
+	}
+
+	return time.Time{}, ErrDataEmpty
+}
+
+// TradeDatesNum 获取区间日期内的交易日天数
+func (ins *Choice) TradeDatesNum(
+	start, end time.Time, options Option,
+) (int, error) {
+	fn, err := ins.checkLibFn("tradedatesnum")
+	if err != nil {
+		return 0, err
+	}
+
+	cStart := C.CString(start.Format("2006-01-02"))
+	cEnd := C.CString(end.Format("2006-01-02"))
+	cOptions := optionCString(options)
+	defer freeCStrings(cStart, cEnd, cOptions)
+
+	var num C.int
+	if err := ins.invoke(context.Background(), "tradedatesnum", func() (int, error) {
+		return 1, ins.checkError(C.CallPChar3PInt(
+			fn, cStart, cEnd, cOptions, &num,
+		))
+	}); err != nil {
+		return 0, err
+	}
+
+	return int(num), nil
+}
+
+func (ins *Choice) callMinuteData(
+	name, code string,
+	indicators []string,
+	start, end time.Time,
+	options Option,
+) (*EQData, error) {
+	fn, err := ins.checkLibFn(name)
+	if err != nil {
+		return nil, err
+	}
+
+	startStr, endStr, err := minuteArgs(name, code, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	cCode, cIndicators, cOptions, err := checkCommonArgs(
+		[]string{code}, indicators, options,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cStart, cEnd := C.CString(startStr), C.CString(endStr)
+
+	return ins.callPData(name,
+		fn, cCode, cIndicators, cStart, cEnd, cOptions,
+	)
+}
+
+// Csc 历史分钟K线, 仅支持单个证券代码
+func (ins *Choice) Csc(
+	code string, indicators []string,
+	start, end time.Time,
+	options Option,
+) (*EQData, error) {
+	return ins.callMinuteData("csc", code, indicators, start, end, options)
+}
+
+// Cmc 历史分时行情, 仅支持单个证券代码
+func (ins *Choice) Cmc(
+	code string, indicators []string,
+	start, end time.Time,
+	options Option,
+) (*EQData, error) {
+	return ins.callMinuteData("cmc", code, indicators, start, end, options)
+}
+
+// Chmc 专项历史分时行情, 仅支持单个证券代码
+func (ins *Choice) Chmc(
+	code string, indicators []string,
+	start, end time.Time,
+	options Option,
+) (*EQData, error) {
+	return ins.callMinuteData("chmc", code, indicators, start, end, options)
+}
+
+func (ins *Choice) callSnapshot(
+	name string, codes, indicators []string, options Option,
+) (*EQData, error) {
+	fn, err := ins.checkLibFn(name)
+	if err != nil {
+		return nil, err
+	}
+
+	cCodes, cIndicators, cOptions, err := checkCommonArgs(codes, indicators, options)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ins.callPData(name, fn, cCodes, cIndicators, cOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 返回数据同时包含DATE及TIME指标时, 行时间由其解析为快照行情时间
+	data.quoteTime = hasQuoteTime(data.indicators)
+
+	return data, nil
+}
+
+// CsqSnapshot 实时行情快照, 指标含DATE及TIME时行数据Date为快照行情时间
+func (ins *Choice) CsqSnapshot(
+	codes, indicators []string, options Option,
+) (*EQData, error) {
+	return ins.callSnapshot("csqsnapshot", codes, indicators, options)
+}
+
+// ChqSnapshot 专项行情快照, 指标含DATE及TIME时行数据Date为快照行情时间
+func (ins *Choice) ChqSnapshot(
+	codes, indicators []string, options Option,
+) (*EQData, error) {
+	return ins.callSnapshot("chqsnapshot", codes, indicators, options)
+}
+
+func (ins *Choice) subscribe(
+	ctx context.Context, name string,
+	fn *[0]byte, token uintptr, args ...*C.char,
+) (int, error) {
+	defer freeCStrings(args...)
+
+	var call func(rtn *C.EQErr) C.EQID
+
+	switch len(args) {
+	case 3:
+		call = func(rtn *C.EQErr) C.EQID {
+			return C.CallPChar3Async(
+				fn, args[0], args[1], args[2],
+				C.datacallback(unsafe.Pointer(C.cDataCallback)),
+				C.uintptr_t(token), rtn,
+			)
+		}
+	case 5:
+		call = func(rtn *C.EQErr) C.EQID {
+			return C.CallPChar5Async(
+				fn, args[0], args[1], args[2], args[3], args[4],
+				C.datacallback(unsafe.Pointer(C.cDataCallback)),
+				C.uintptr_t(token), rtn,
+			)
+		}
+	default:
+		return 0, fmt.Errorf(
+			"%w: unsupported args count: %d", ErrInvalidArgs, len(args),
+		)
+	}
+
+	var serialID C.EQID
+
+	// 推送数据经回调到达, 订阅请求本身不计数据点
+	if err := ins.invoke(ctx, name, func() (int, error) {
+		var rtn C.EQErr
+
+		serialID = call(&rtn)
+
+		return 0, ins.checkError(rtn)
+	}); err != nil {
+		return 0, err
+	}
+
+	return int(serialID), nil
+}
+
+func (ins *Choice) cancelAsync(name string, serialID int) error {
+	fn, err := ins.checkLibFn(name)
+	if err != nil {
+		return err
+	}
+
+	return ins.checkError(C.CallAsyncCanceler(fn, C.EQID(serialID)))
+}
+
+func startSubscription[T any](
+	ctx context.Context, ins *Choice,
+	name, cancelName string,
+	convert func(msg *EQMsg) []T,
+	args ...*C.char,
+) (*Subscription[T], error) {
+	fn, err := ins.checkLibFn(name)
+	if err != nil {
+		freeCStrings(args...)
+		return nil, err
+	}
+
+	// 取消函数缺失时订阅无法退订, 不允许发起订阅
+	if _, err := ins.checkLibFn(cancelName); err != nil {
+		freeCStrings(args...)
+		return nil, err
+	}
+
+	if !ins.isStarted() {
+		freeCStrings(args...)
+		return nil, fmt.Errorf(
+			"%w: choice api not started", ErrInitialized,
+		)
+	}
+
+	sub := newSubscription(ctx, ins, convert)
+	sub.cancelFn = func(serialID int) error {
+		return ins.cancelAsync(cancelName, serialID)
+	}
+
+	// 保存Go侧参数副本, 重新订阅时重建C字符串
+	saved := make([]*string, len(args))
+	for idx, arg := range args {
+		if arg != nil {
+			v := C.GoString(arg)
+			saved[idx] = &v
+		}
+	}
+
+	sub.resubscribe = func(token uintptr) (int, error) {
+		cArgs := make([]*C.char, len(saved))
+		for idx, arg := range saved {
+			if arg != nil {
+				cArgs[idx] = C.CString(*arg)
+			}
+		}
+
+		return ins.subscribe(sub.ctx, name, fn, token, cArgs...)
+	}
+
+	serialID, err := ins.subscribe(sub.ctx, name, fn, sub.token, args...)
+	if err != nil {
+		sub.Cancel()
+		return nil, err
+	}
+	sub.bind(serialID)
+	ins.subscriptions.Store(sub.token, sub)
+
+	sub.watch()
+
+	return sub, nil
+}
+
+// Csq 订阅实时行情, ctx结束时自动取消订阅
+func (ins *Choice) Csq(
+	ctx context.Context,
+	codes, indicators []string,
+	options Option,
+) (*QuoteSubscription, error) {
+	if _, err := ins.checkLibFn("csq"); err != nil {
+		return nil, err
+	}
+
+	cCodes, cIndicators, cOptions, err := checkCommonArgs(codes, indicators, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return startSubscription(
+		ctx, ins, "csq", "csqcancel", convertQuotes,
+		cCodes, cIndicators, cOptions,
+	)
+}
+
+// Chq 订阅专项行情(如Level-2), ctx结束时自动取消订阅
+func (ins *Choice) Chq(
+	ctx context.Context,
+	codes, indicators []string,
+	options Option,
+) (*QuoteSubscription, error) {
+	if _, err := ins.checkLibFn("chq"); err != nil {
+		return nil, err
+	}
+
+	cCodes, cIndicators, cOptions, err := checkCommonArgs(codes, indicators, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return startSubscription(
+		ctx, ins, "chq", "chqcancel", convertQuotes,
+		cCodes, cIndicators, cOptions,
+	)
+}
+
+// Cnq 订阅资讯及公告推送, codes为东财代码或板块代码(不可混合), ctx结束时自动取消订阅
+func (ins *Choice) Cnq(
+	ctx context.Context,
+	codes []string, content string,
+	options Option,
+) (*NewsSubscription, error) {
+	if _, err := ins.checkLibFn("cnq"); err != nil {
+		return nil, err
+	}
+
+	if len(codes) <= 0 || content == "" {
+		return nil, fmt.Errorf(
+			"%w: codes or content is empty", ErrInvalidArgs,
+		)
+	}
+
+	var cOptions *C.char
+	if options != nil {
+		cOptions = C.CString(options.OptionString())
+	}
+
+	return startSubscription(
+		ctx, ins, "cnq", "cnqcancel", convertNews,
+		C.CString(strings.Join(codes, ",")), C.CString(content), cOptions,
+	)
+}
+
+func (ins *Choice) startCst(
+	ctx context.Context,
+	codes, indicators []string,
+	start, end time.Time,
+	options Option,
+) (*asyncRequest, error) {
+	fn, err := ins.checkLibFn("cst")
+	if err != nil {
+		return nil, err
+	}
+
+	if !ins.isStarted() {
+		return nil, fmt.Errorf(
+			"%w: choice api not started", ErrInitialized,
+		)
+	}
+
+	if end.Before(start) {
+		return nil, fmt.Errorf(
+			"%w: end time before start time", ErrInvalidArgs,
+		)
+	}
+
+	cCodes, cIndicators, cOptions, err := checkCommonArgs(codes, indicators, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// cst时间精度为分钟, 秒位固定补0
+	cStart := C.CString(start.Format("200601021504") + "00")
+	cEnd := C.CString(end.Format("200601021504") + "00")
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req := newAsyncRequest(ctx)
+
+	serialID, err := ins.subscribe(
+		ctx, "cst", fn, req.token, cCodes, cIndicators, cStart, cEnd, cOptions,
+	)
+	if err != nil {
+		req.close()
+		return nil, err
+	}
+	msgDispatcher.bindSerial(req.token, serialID)
+
+	return req, nil
+}
+
+// Sector 获取系统板块在指定交易日的成分证券
+func (ins *Choice) Sector(
+	pukeyCode string, tradeDate time.Time, options Option,
+) ([]SectorConstituent, error) {
+	fn, err := ins.checkLibFn("sector")
+	if err != nil {
+		return nil, err
+	}
+
+	if pukeyCode == "" {
+		return nil, fmt.Errorf(
+			"%w: sector code is empty", ErrInvalidArgs,
+		)
+	}
+
+	var cOptions *C.char
+	if options != nil {
+		cOptions = C.CString(options.OptionString())
+	}
+
+	data, err := ins.callPData("sector",
+		fn, C.CString(pukeyCode),
+		C.CString(tradeDate.Format("2006-01-02")), cOptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSectorConstituents(data)
+}
+
+// Ctr 获取专题报表
+func (ins *Choice) Ctr(
+	ctrName string, indicators []string, options Option,
+) (*EQCtrData, error) {
+	fn, err := ins.checkLibFn("ctr")
+	if err != nil {
+		return nil, err
+	}
+
+	if ctrName == "" {
+		return nil, fmt.Errorf(
+			"%w: ctr name is empty", ErrInvalidArgs,
+		)
+	}
+
+	var cOptions *C.char
+	if options != nil {
+		cOptions = C.CString(options.OptionString())
+	}
+
+	return ins.callPCtrData("ctr",
+		fn, C.CString(ctrName),
+		C.CString(strings.Join(indicators, ",")), cOptions,
+	)
+}
+
+// Edb 宏观指标数据查询
+func (ins *Choice) Edb(ids []string, options Option) (*EdbData, error) {
+	fn, err := ins.checkLibFn("edb")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) <= 0 {
+		return nil, fmt.Errorf(
+			"%w: edb ids is empty", ErrInvalidArgs,
+		)
+	}
+
+	var cOptions *C.char
+	if options != nil {
+		cOptions = C.CString(options.OptionString())
+	}
+
+	data, err := ins.callPData("edb",
+		fn, C.CString(strings.Join(ids, ",")), cOptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EdbData{data: data}, nil
+}
+
+// EdbQuery 宏观指标ID详情查询
+func (ins *Choice) EdbQuery(
+	ids, indicators []string, options Option,
+) (*EQData, error) {
+	fn, err := ins.checkLibFn("edbquery")
+	if err != nil {
+		return nil, err
+	}
+
+	cIDs, cIndicators, cOptions, err := checkCommonArgs(ids, indicators, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return ins.callPData("edbquery", fn, cIDs, cIndicators, cOptions)
+}
+
+// Cfn 资讯数据查询, codes为东财代码或板块代码(不可混合)
+func (ins *Choice) Cfn(
+	codes []string, content string,
+	mode cfnMode, options Option,
+) ([]NewsItem, error) {
+	fn, err := ins.checkLibFn("cfn")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(codes) <= 0 || content == "" {
+		return nil, fmt.Errorf(
+			"%w: codes or content is empty", ErrInvalidArgs,
+		)
+	}
+
+	switch mode {
+	case CfnStartToEnd, CfnEndCount:
+	default:
+		return nil, fmt.Errorf(
+			"%w: unknown cfn mode %s", ErrInvalidArgs, mode,
+		)
+	}
+
+	cCodes := C.CString(strings.Join(codes, ","))
+	cContent := C.CString(content)
+
+	var cOptions *C.char
+	if options != nil {
+		cOptions = C.CString(options.OptionString())
+	}
+	defer freeCStrings(cCodes, cContent, cOptions)
+
+	data, err := ins.queryPData(context.Background(), "cfn", func(pData **C.EQDATA) C.EQErr {
+		return C.CallCfnQuerier(
+			fn, cCodes, cContent, C.eCfnMode(mode), cOptions, pData,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newNewsItems(data)
+}
+
+// CfnQuery 资讯板块树查询
+func (ins *Choice) CfnQuery(options Option) ([]NewsSector, error) {
+	fn, err := ins.checkLibFn("cfnquery")
+	if err != nil {
+		return nil, err
+	}
+
+	var cOptions *C.char
+	if options != nil {
+		cOptions = C.CString(options.OptionString())
+	}
+
+	data, err := ins.callPData("cfnquery", fn, cOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return newNewsSectors(data)
+}
+
+// Cps 条件选股, codes为板块代码(以B_开头)或东财代码列表
+func (ins *Choice) Cps(
+	codes []string,
+	indicators *cpsIndicators,
+	conditions CpsCondition,
+	options Option,
+) (*EQData, error) {
+	fn, err := ins.checkLibFn("cps")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(codes) <= 0 || indicators == nil || len(indicators.exprs) <= 0 {
+		return nil, fmt.Errorf(
+			"%w: codes or indicators is empty", ErrInvalidArgs,
+		)
+	}
+
+	if conditions.IsZero() {
+		return nil, fmt.Errorf(
+			"%w: conditions is empty", ErrInvalidArgs,
+		)
+	}
+
+	var cOptions *C.char
+	if options != nil {
+		cOptions = C.CString(options.OptionString())
+	}
+
+	return ins.callPData("cps",
+		fn, C.CString(strings.Join(codes, ",")),
+		C.CString(indicators.String()),
+		C.CString(conditions.String()),
+		cOptions,
+	)
+}
+
+func optionCString(options Option) *C.char {
+	if options == nil {
+		return nil
+	}
+
+	return C.CString(options.OptionString())
+}
+
+func (ins *Choice) pcreate(
+	code, name string, initialFund int64,
+	remark string, options Option,
+) error {
+	fn, err := ins.checkLibFn("pcreate")
+	if err != nil {
+		return err
+	}
+
+	cCode, cName, cRemark := C.CString(code), C.CString(name), C.CString(remark)
+	cOptions := optionCString(options)
+	defer freeCStrings(cCode, cName, cRemark, cOptions)
+
+	return ins.invoke(context.Background(), "pcreate", func() (int, error) {
+		return 0, ins.checkError(C.CallPortfolioCreator(
+			fn, cCode, cName, C.int64_t(initialFund), cRemark, cOptions,
+		))
+	})
+}
+
+func (ins *Choice) pdelete(code string, options Option) error {
+	fn, err := ins.checkLibFn("pdelete")
+	if err != nil {
+		return err
+	}
+
+	cCode, cOptions := C.CString(code), optionCString(options)
+	defer freeCStrings(cCode, cOptions)
+
+	return ins.invoke(context.Background(), "pdelete", func() (int, error) {
+		return 0, ins.checkError(C.CallPChar2Exec(fn, cCode, cOptions))
+	})
+}
+
+func (ins *Choice) porder(
+	code string, orders []Order, remark string, options Option,
+) error {
+	fn, err := ins.checkLibFn("porder")
+	if err != nil {
+		return err
+	}
+
+	cOrders := (*C.ORDERINFO)(C.calloc(
+		C.size_t(len(orders)), C.size_t(unsafe.Sizeof(C.ORDERINFO{})),
+	))
+	defer C.free(unsafe.Pointer(cOrders))
+
+	infos := unsafe.Slice(cOrders, len(orders))
+	for idx, order := range orders {
+		info := &infos[idx]
+
+		cOrderCode := C.CString(order.Code)
+		C.strncpy(&info.code[0], cOrderCode, C.size_t(len(info.code)-1))
+		C.free(unsafe.Pointer(cOrderCode))
+
+		info.volume = C.double(order.Volume)
+		info.price = C.float(order.Price)
+		info.optype = C.OperateType(order.Operate)
+		info.cost = C.float(order.Cost)
+		info.rate = C.float(order.Rate)
+
+		date, clock := orderDateTime(order.Time)
+		info.date = C.int(date)
+		info.time = C.int(clock)
+	}
+
+	cCode, cRemark := C.CString(code), C.CString(remark)
+	cOptions := optionCString(options)
+	defer freeCStrings(cCode, cRemark, cOptions)
+
+	return ins.invoke(context.Background(), "porder", func() (int, error) {
+		return 0, ins.checkError(C.CallOrderExecutor(
+			fn, cOrders, C.int(len(orders)), cCode, cRemark, cOptions,
+		))
+	})
+}
+
+func (ins *Choice) pctransfer(
+	code string, direction transferDirection,
+	date time.Time, cash float64,
+	remark string, options Option,
+) error {
+	fn, err := ins.checkLibFn("pctransfer")
+	if err != nil {
+		return err
+	}
+
+	cCode, cDirection := C.CString(code), C.CString(string(direction))
+	cDate, cRemark := C.CString(date.Format("2006-01-02")), C.CString(remark)
+	cOptions := optionCString(options)
+	defer freeCStrings(cCode, cDirection, cDate, cRemark, cOptions)
+
+	return ins.invoke(context.Background(), "pctransfer", func() (int, error) {
+		return 0, ins.checkError(C.CallCashTransfer(
+			fn, cCode, cDirection, cDate, C.double(cash), cRemark, cOptions,
+		))
+	})
+}
+
+func (ins *Choice) pquery(options Option) (*EQData, error) {
+	fn, err := ins.checkLibFn("pquery")
+	if err != nil {
+		return nil, err
+	}
+
+	return ins.callPData("pquery", fn, optionCString(options))
+}
+
+func (ins *Choice) preport(
+	code, indicator string, options Option,
+) (*EQData, error) {
+	fn, err := ins.checkLibFn("preport")
+	if err != nil {
+		return nil, err
+	}
+
+	return ins.callPData("preport",
+		fn, C.CString(code), C.CString(indicator), optionCString(options),
+	)
+}
+
+// ValidateCodes 校验或补全东财证券代码, 校验模式由options中的ReturnType决定(默认校验)
+func (ins *Choice) ValidateCodes(
+	codes []string, options Option,
+) ([]CodeValidation, error) {
+	fn, err := ins.checkLibFn("cec")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(codes) <= 0 {
+		return nil, fmt.Errorf(
+			"%w: codes is empty", ErrInvalidArgs,
+		)
+	}
+
+	if options == nil {
+		options = NewCecOptions().ReturnType(CecVerify)
+	}
+
+	data, err := ins.callPCtrData("cec",
+		fn, C.CString(strings.Join(codes, ",")), optionCString(options),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCodeValidations(data, cecMode(options))
+}
+
+// validateIndicators 校验证券与指标, 返回按证券品种分组的csd/css/cses请求参数
+//
+// cfc返回列尚未经实际返回数据确认, 确认前不对外导出
+func (ins *Choice) validateIndicators(
+	codes, indicators []string, fun funType,
+) ([]IndicatorValidation, error) {
+	fn, err := ins.checkLibFn("cfc")
+	if err != nil {
+		return nil, err
+	}
+
+	switch fun {
+	case FunCSD, FunCSS, FunCSES:
+	default:
+		return nil, fmt.Errorf(
+			"%w: unknown fun type %q", ErrInvalidArgs, fun,
+		)
+	}
+
+	if len(codes) <= 0 || len(indicators) <= 0 {
+		return nil, fmt.Errorf(
+			"%w: codes or indicators is empty", ErrInvalidArgs,
+		)
+	}
+
+	data, err := ins.callPCtrData("cfc",
+		fn, C.CString(strings.Join(codes, ",")),
+		C.CString(strings.Join(indicators, ",")),
+		C.CString("FunType="+string(fun)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newIndicatorValidations(data)
+}
+
+// SetProxy 设置网络代理, 必须在Start之前调用
+func (ins *Choice) SetProxy(cfg *ProxyConfig) error {
+	fn, err := ins.checkLibFn("setproxy")
+	if err != nil {
+		return err
+	}
+
+	if ins.isStarted() {
+		return fmt.Errorf(
+			"%w: proxy must be set before start", ErrAlreadyStarted,
+		)
+	}
+
+	if cfg == nil {
+		cfg = &ProxyConfig{Type: ProxyNone}
+	}
+
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+
+	cHost := C.CString(cfg.Host)
+	cUser := C.CString(cfg.User)
+	cPass := C.CString(cfg.Password)
+	defer freeCStrings(cHost, cUser, cPass)
+
+	slog.Info(
+		"choice set network proxy",
+		slog.String("proxy", cfg.String()),
+	)
+
+	return ins.checkError(C.CallProxySetter(
+		fn, C.ProxyType(cfg.Type), cHost, C.ushort(cfg.Port),
+		C.bool(cfg.Verify), cUser, cPass,
+	))
 }
@@ -0,0 +1,259 @@
+package choice4go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ChunkPlan 大请求拆分参数, 各项不大于0时该维度不拆分
+type ChunkPlan struct {
+	// MaxCodes 每个子请求的最大证券数
+	MaxCodes int
+	// MaxIndicators 每个子请求的最大指标数, 超过MAX_INDICATOR_COUNT时按其截断
+	MaxIndicators int
+	// MaxDays 每个子请求的最大自然日跨度, 仅对Csd生效
+	//
+	// 非日频(Period不为1)时子区间按周期边界截断, 每个子请求至少包含一个完整周期.
+	MaxDays int
+	// Parallel 子请求并发数, 不大于1时顺序执行
+	Parallel int
+}
+
+var DefaultChunkPlan = ChunkPlan{
+	MaxCodes:      500,
+	MaxIndicators: MAX_INDICATOR_COUNT,
+	MaxDays:       366,
+	Parallel:      1,
+}
+
+type chunk struct {
+	codes      []string
+	indicators []string
+	start, end time.Time
+}
+
+func splitStrings(values []string, size int) [][]string {
+	if size <= 0 || len(values) <= size {
+		return [][]string{values}
+	}
+
+	results := make([][]string, 0, (len(values)+size-1)/size)
+	for start := 0; start < len(values); start += size {
+		results = append(results, values[start:min(start+size, len(values))])
+	}
+
+	return results
+}
+
+// periodEnd 返回t所在日期周期的最后一个自然日, 周以周日结束
+func periodEnd(t time.Time, p period) time.Time {
+	switch p {
+	case Weekly:
+		return t.AddDate(0, 0, (7-int(t.Weekday()))%7)
+	case Monthly:
+		return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location())
+	case Yearly:
+		return time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, t.Location())
+	default:
+		return t
+	}
+}
+
+// csdPeriod 解析Csd参数中的日期周期, 未设置时为日频
+func csdPeriod(options Option) period {
+	v, ok := lookupOption(options, "Period")
+	if !ok {
+		return Daily
+	}
+
+	p, err := strconv.Atoi(v)
+	if err != nil || p < int(Daily) || p > int(Yearly) {
+		return Daily
+	}
+
+	return period(p)
+}
+
+// splitDates 将[start, end]按自然日拆分为首尾相接且互不重叠的区间
+//
+// 非日频时区间在周期边界处截断, 避免同一周期被拆入两个子请求而返回重复的不完整周期数据.
+func splitDates(start, end time.Time, maxDays int, p period) [][2]time.Time {
+	start, end = truncateDay(start), truncateDay(end)
+
+	if maxDays <= 0 || !start.AddDate(0, 0, maxDays).Before(end.AddDate(0, 0, 1)) {
+		return [][2]time.Time{{start, end}}
+	}
+
+	var results [][2]time.Time
+	for from := start; !from.After(end); {
+		limit := from.AddDate(0, 0, maxDays-1)
+
+		to := periodEnd(from, p)
+		for {
+			next := periodEnd(to.AddDate(0, 0, 1), p)
+			if next.After(limit) {
+				break
+			}
+
+			to = next
+		}
+
+		if to.After(end) {
+			to = end
+		}
+
+		results = append(results, [2]time.Time{from, to})
+		from = to.AddDate(0, 0, 1)
+	}
+
+	return results
+}
+
+// split 按证券、指标、日期顺序拆分, 保证合并后证券及指标保持请求顺序
+func (p *ChunkPlan) split(
+	codes, indicators []string,
+	start, end time.Time, withDates bool, datePeriod period,
+) []chunk {
+	maxIndicators := p.MaxIndicators
+	if maxIndicators <= 0 || maxIndicators > MAX_INDICATOR_COUNT {
+		maxIndicators = MAX_INDICATOR_COUNT
+	}
+
+	dates := [][2]time.Time{{start, end}}
+	if withDates {
+		dates = splitDates(start, end, p.MaxDays, datePeriod)
+	}
+
+	var results []chunk
+	for _, codeChunk := range splitStrings(codes, p.MaxCodes) {
+		for _, indicatorChunk := range splitStrings(indicators, maxIndicators) {
+			for _, dateRange := range dates {
+				results = append(results, chunk{
+					codes:      codeChunk,
+					indicators: indicatorChunk,
+					start:      dateRange[0],
+					end:        dateRange[1],
+				})
+			}
+		}
+	}
+
+	return results
+}
+
+// runChunks 执行子请求并合并结果, 首个非空数据错误发生后取消其余未完成的子请求
+func runChunks(
+	ctx context.Context, chunks []chunk, parallel int,
+	query func(ctx context.Context, c chunk) (*EQData, error),
+) (*EQData, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		failOnce sync.Once
+		failed   error
+	)
+
+	fail := func(idx int, err error) {
+		failOnce.Do(func() {
+			failed = fmt.Errorf(
+				"query chunk %d/%d failed: %w", idx+1, len(chunks), err,
+			)
+			cancel()
+		})
+	}
+
+	results, _ := queryParallel(len(chunks), parallel, func(idx int) (*EQData, error) {
+		if err := ctx.Err(); err != nil {
+			fail(idx, err)
+			return nil, err
+		}
+
+		data, err := query(ctx, chunks[idx])
+		if err != nil && !errors.Is(err, ErrDataEmpty) && !errors.Is(err, ErrNoData) {
+			fail(idx, err)
+		}
+
+		return data, err
+	})
+
+	if failed != nil {
+		return nil, failed
+	}
+
+	return mergeEQData(results...)
+}
+
+func checkChunkArgs(codes, indicators []string) error {
+	if len(codes) <= 0 || len(indicators) <= 0 {
+		return fmt.Errorf(
+			"%w: codes or indicators is empty", ErrInvalidArgs,
+		)
+	}
+
+	return nil
+}
+
+// CsdChunked 将大规模序列数据请求按plan拆分为子请求执行, 并合并为一个数据立方
+//
+// plan为nil时使用DefaultChunkPlan, 子请求均经过限流及重试;
+// 任一子请求失败或ctx结束时取消其余子请求.
+func (ins *Choice) CsdChunked(
+	ctx context.Context,
+	codes, indicators []string,
+	start, end time.Time,
+	options Option,
+	plan *ChunkPlan,
+) (*EQData, error) {
+	if err := checkChunkArgs(codes, indicators); err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		plan = &DefaultChunkPlan
+	}
+
+	return runChunks(
+		ctx,
+		plan.split(
+			codes, indicators, start, end, true, csdPeriod(options),
+		),
+		plan.Parallel,
+		func(ctx context.Context, c chunk) (*EQData, error) {
+			return ins.CsdContext(ctx, c.codes, c.indicators, c.start, c.end, options)
+		},
+	)
+}
+
+// CssChunked 将大规模截面数据请求按plan拆分为子请求执行, 并合并为一个数据立方
+func (ins *Choice) CssChunked(
+	ctx context.Context,
+	codes, indicators []string,
+	options Option,
+	plan *ChunkPlan,
+) (*EQData, error) {
+	if err := checkChunkArgs(codes, indicators); err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		plan = &DefaultChunkPlan
+	}
+
+	return runChunks(
+		ctx,
+		plan.split(codes, indicators, time.Time{}, time.Time{}, false, Daily),
+		plan.Parallel,
+		func(ctx context.Context, c chunk) (*EQData, error) {
+			return ins.CssContext(ctx, c.codes, c.indicators, options)
+		},
+	)
+}
@@ -0,0 +1,257 @@
+package choice4go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSplitDates(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)
+
+	ranges := splitDates(start, end, 4, Daily)
+	if len(ranges) != 3 {
+		t.Fatalf("unexpected range count: %d", len(ranges))
+	}
+
+	if !ranges[0][0].Equal(start) || !ranges[2][1].Equal(end) {
+		t.Fatalf("ranges not cover whole period: %v", ranges)
+	}
+
+	for idx := 1; idx < len(ranges); idx++ {
+		if !ranges[idx][0].Equal(ranges[idx-1][1].AddDate(0, 0, 1)) {
+			t.Fatalf("ranges not continuous: %v", ranges)
+		}
+	}
+
+	if ranges := splitDates(start, end, 10, Daily); len(ranges) != 1 {
+		t.Fatalf("period within max days should not split: %v", ranges)
+	}
+}
+
+func TestChunkPlanSplit(t *testing.T) {
+	codes := make([]string, 5000)
+	for idx := range codes {
+		codes[idx] = fmt.Sprintf("%06d.SZ", idx)
+	}
+
+	indicators := make([]string, 80)
+	for idx := range indicators {
+		indicators[idx] = fmt.Sprintf("IND%d", idx)
+	}
+
+	plan := ChunkPlan{MaxCodes: 1000, MaxIndicators: 100}
+	chunks := plan.split(codes, indicators, time.Time{}, time.Time{}, false, Daily)
+
+	// 指标数按MAX_INDICATOR_COUNT截断: 5组证券 × 2组指标
+	if len(chunks) != 10 {
+		t.Fatalf("unexpected chunk count: %d", len(chunks))
+	}
+
+	var (
+		gotCodes, gotIndicators []string
+		codeIdx                 = make(map[string]int)
+		indicatorIdx            = make(map[string]int)
+	)
+	for _, c := range chunks {
+		if len(c.indicators) > MAX_INDICATOR_COUNT {
+			t.Fatalf("chunk indicators exceeded: %d", len(c.indicators))
+		}
+
+		gotCodes = appendUnique(gotCodes, codeIdx, c.codes)
+		gotIndicators = appendUnique(gotIndicators, indicatorIdx, c.indicators)
+	}
+
+	if !slices.Equal(gotCodes, codes) || !slices.Equal(gotIndicators, indicators) {
+		t.Fatal("chunks not preserve request order")
+	}
+}
+
+func TestSplitDatesPeriod(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 6, 30, 0, 0, 0, 0, time.Local)
+
+	for _, p := range []period{Weekly, Monthly, Yearly} {
+		ranges := splitDates(start, end, 40, p)
+
+		for idx, dateRange := range ranges {
+			if idx > 0 && !dateRange[0].Equal(ranges[idx-1][1].AddDate(0, 0, 1)) {
+				t.Fatalf("%s ranges not continuous: %v", p, ranges)
+			}
+
+			if idx < len(ranges)-1 && !periodEnd(dateRange[1], p).Equal(dateRange[1]) {
+				t.Fatalf("%s range %v not aligned to period end", p, dateRange)
+			}
+		}
+
+		if !ranges[len(ranges)-1][1].Equal(end) {
+			t.Fatalf("%s ranges not cover whole period: %v", p, ranges)
+		}
+	}
+}
+
+func TestCsdPeriod(t *testing.T) {
+	if p := csdPeriod(NewCsdOptions().Period(Monthly)); p != Monthly {
+		t.Fatalf("expected monthly period, got %s", p)
+	}
+
+	if p := csdPeriod(nil); p != Daily {
+		t.Fatalf("expected daily period by default, got %s", p)
+	}
+}
+
+// fakeCsd 模拟csd按周期返回数据, 每个周期取区间内最后一个自然日, 值为日期数字
+func fakeCsd(c chunk, p period) (*EQData, error) {
+	var (
+		dates  []string
+		values []float64
+	)
+
+	for from := c.start; !from.After(c.end); {
+		to := periodEnd(from, p)
+		if to.After(c.end) {
+			to = c.end
+		}
+
+		dates = append(dates, to.Format("2006/1/2"))
+		for range c.codes {
+			for range c.indicators {
+				values = append(values, float64(
+					to.Year()*10000+int(to.Month())*100+to.Day(),
+				))
+			}
+		}
+
+		from = to.AddDate(0, 0, 1)
+	}
+
+	return newTestEQData(c.codes, c.indicators, dates, values...), nil
+}
+
+func TestRunChunksMerge(t *testing.T) {
+	codes := []string{"000001.SZ", "600000.SH", "300059.SZ"}
+	indicators := []string{"CLOSE"}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.Local)
+
+	plan := ChunkPlan{MaxCodes: 2, MaxDays: 20, Parallel: 3}
+	options := NewCsdOptions().Period(Weekly)
+
+	chunks := plan.split(codes, indicators, start, end, true, csdPeriod(options))
+	merged, err := runChunks(
+		context.Background(), chunks, plan.Parallel,
+		func(_ context.Context, c chunk) (*EQData, error) {
+			return fakeCsd(c, Weekly)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := fakeCsd(chunk{
+		codes: codes, indicators: indicators, start: start, end: end,
+	}, Weekly)
+
+	if !slices.Equal(merged.codes, codes) {
+		t.Fatalf("codes order changed: %v", merged.codes)
+	}
+
+	if !slices.Equal(merged.dateList, expected.dateList) {
+		t.Fatalf(
+			"merged dates mismatch:\nexpected %v\ngot      %v",
+			expected.dateList, merged.dateList,
+		)
+	}
+
+	var last time.Time
+	for _, row := range merged.Iter() {
+		if row.Date.Before(last) {
+			t.Fatalf("rows not in date order at %s %s", row.Code, row.Date)
+		}
+		last = row.Date
+
+		v, _ := row.Value("CLOSE")
+		if got, _ := v.AsInt64(); got != int64(
+			row.Date.Year()*10000+int(row.Date.Month())*100+row.Date.Day(),
+		) {
+			t.Fatalf("%s %s: unexpected value %v", row.Code, row.Date, v.GetValue())
+		}
+	}
+}
+
+func TestRunChunksCancel(t *testing.T) {
+	codes := []string{"000001.SZ", "600000.SH", "300059.SZ", "600519.SH"}
+	plan := ChunkPlan{MaxCodes: 1, Parallel: 4}
+	chunks := plan.split(codes, []string{"CLOSE"}, time.Time{}, time.Time{}, false, Daily)
+
+	// 首个子请求失败, 其余子请求阻塞至ctx取消
+	_, err := runChunks(
+		context.Background(), chunks, plan.Parallel,
+		func(ctx context.Context, c chunk) (*EQData, error) {
+			if c.codes[0] == codes[2] {
+				return nil, ErrTimeout
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				t.Error("chunk not canceled after failure")
+				return nil, nil
+			}
+		},
+	)
+	if !errors.Is(err, ErrTimeout) || errors.Is(err, context.Canceled) {
+		t.Fatalf("expect first chunk error, got %v", err)
+	}
+
+	// 顺序执行时失败后的子请求不再发起
+	var calls atomic.Int32
+	_, err = runChunks(
+		context.Background(), chunks, 1,
+		func(_ context.Context, c chunk) (*EQData, error) {
+			calls.Add(1)
+			if c.codes[0] == codes[1] {
+				return nil, ErrNetwork
+			}
+
+			return newTestEQData(c.codes, c.indicators, []string{"2024/1/2"}, 1), nil
+		},
+	)
+	if !errors.Is(err, ErrNetwork) || calls.Load() != 2 {
+		t.Fatalf("unexpected result after failure: %d calls, %v", calls.Load(), err)
+	}
+
+	// 空数据不视为失败
+	merged, err := runChunks(
+		context.Background(), chunks, 2,
+		func(_ context.Context, c chunk) (*EQData, error) {
+			if c.codes[0] == codes[0] {
+				return nil, ErrNoData
+			}
+
+			return newTestEQData(c.codes, c.indicators, []string{"2024/1/2"}, 1), nil
+		},
+	)
+	if err != nil || !slices.Equal(merged.codes, codes[1:]) {
+		t.Fatalf("empty chunk should be skipped: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := runChunks(
+		ctx, chunks, 2,
+		func(_ context.Context, c chunk) (*EQData, error) {
+			t.Error("chunk queried after ctx canceled")
+			return nil, nil
+		},
+	); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expect canceled error, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package choice4go
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+type cpsExpr struct {
+	alias     string
+	indicator string
+	params    []string
+}
+
+// cpsIndicators 条件选股表达式参数, 格式如: s1,open,2016/12/13,1;s2,close,2017-02-25,1;s3,listdate
+type cpsIndicators struct {
+	exprs []cpsExpr
+}
+
+func NewCpsIndicators() *cpsIndicators {
+	return &cpsIndicators{}
+}
+
+func formatCpsParam(v any) string {
+	switch param := v.(type) {
+	case string:
+		return param
+	case time.Time:
+		return param.Format("2006/01/02")
+	case fmt.Stringer:
+		return param.String()
+	default:
+		return fmt.Sprint(param)
+	}
+}
+
+// Add 添加表达式, alias为条件表达式中引用的别名, params为指标参数(如日期、复权方式)
+func (b *cpsIndicators) Add(
+	alias, indicator string, params ...any,
+) *cpsIndicators {
+	expr := cpsExpr{
+		alias:     alias,
+		indicator: indicator,
+		params:    make([]string, 0, len(params)),
+	}
+
+	for _, param := range params {
+		expr.params = append(expr.params, formatCpsParam(param))
+	}
+
+	b.exprs = append(b.exprs, expr)
+
+	return b
+}
+
+func (b *cpsIndicators) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	for idx, expr := range b.exprs {
+		if idx > 0 {
+			buff.WriteByte(';')
+		}
+
+		buff.WriteString(expr.alias)
+		buff.WriteByte(',')
+		buff.WriteString(expr.indicator)
+
+		for _, param := range expr.params {
+			buff.WriteByte(',')
+			buff.WriteString(param)
+		}
+	}
+
+	return buff.String()
+}
+
+// CpsField 条件表达式中对指标别名的引用, 如 [s1]
+type CpsField string
+
+func (f CpsField) String() string {
+	return "[" + string(f) + "]"
+}
+
+func formatCpsOperand(v any) string {
+	switch operand := v.(type) {
+	case CpsField:
+		return operand.String()
+	case string:
+		return strconv.Quote(operand)
+	case time.Time:
+		return strconv.Quote(operand.Format("2006-01-02"))
+	default:
+		return fmt.Sprint(operand)
+	}
+}
+
+func (f CpsField) compare(op string, v any) CpsCondition {
+	return CpsCondition{expr: f.String() + op + formatCpsOperand(v)}
+}
+
+func (f CpsField) Gt(v any) CpsCondition { return f.compare(">", v) }
+func (f CpsField) Ge(v any) CpsCondition { return f.compare(">=", v) }
+func (f CpsField) Lt(v any) CpsCondition { return f.compare("<", v) }
+func (f CpsField) Le(v any) CpsCondition { return f.compare("<=", v) }
+func (f CpsField) Eq(v any) CpsCondition { return f.compare("==", v) }
+func (f CpsField) Ne(v any) CpsCondition { return f.compare("!=", v) }
+
+// CpsCondition 条件选股条件表达式
+type CpsCondition struct {
+	expr string
+}
+
+// RawCpsCondition 直接使用条件表达式字符串
+func RawCpsCondition(expr string) CpsCondition {
+	return CpsCondition{expr: strings.TrimSpace(expr)}
+}
+
+func (c CpsCondition) IsZero() bool {
+	return c.expr == ""
+}
+
+func (c CpsCondition) join(op string, others ...CpsCondition) CpsCondition {
+	parts := make([]string, 0, len(others)+1)
+
+	for _, cond := range append([]CpsCondition{c}, others...) {
+		if !cond.IsZero() {
+			parts = append(parts, cond.expr)
+		}
+	}
+
+	if len(parts) <= 1 {
+		return CpsCondition{expr: strings.Join(parts, "")}
+	}
+
+	for idx, part := range parts {
+		parts[idx] = "(" + part + ")"
+	}
+
+	return CpsCondition{expr: strings.Join(parts, " "+op+" ")}
+}
+
+func (c CpsCondition) And(others ...CpsCondition) CpsCondition {
+	return c.join("and", others...)
+}
+
+func (c CpsCondition) Or(others ...CpsCondition) CpsCondition {
+	return c.join("or", others...)
+}
+
+func (c CpsCondition) Not() CpsCondition {
+	if c.IsZero() {
+		return c
+	}
+
+	return CpsCondition{expr: "not (" + c.expr + ")"}
+}
+
+func (c CpsCondition) String() string {
+	return c.expr
+}
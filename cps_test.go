@@ -0,0 +1,38 @@
+package choice4go
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCpsBuilder(t *testing.T) {
+	indicators := NewCpsIndicators().
+		Add("s1", "open", time.Date(2016, 12, 13, 0, 0, 0, 0, time.Local), 1).
+		Add("s2", "close", "2017-02-25", 1).
+		Add("s3", "listdate")
+
+	if v := indicators.String(); v != "s1,open,2016/12/13,1;s2,close,2017-02-25,1;s3,listdate" {
+		t.Errorf("unexpected indicators: %s", v)
+	}
+
+	cond := CpsField("s1").Gt(10).
+		And(CpsField("s2").Le(CpsField("s1"))).
+		Or(CpsField("s3").Ne("2020-01-01").Not())
+
+	if v := cond.String(); v != `(([s1]>10) and ([s2]<=[s1])) or (not ([s3]!="2020-01-01"))` {
+		t.Errorf("unexpected condition: %s", v)
+	}
+
+	if v := CpsField("s1").Gt(1).And(CpsCondition{}).String(); v != "[s1]>1" {
+		t.Errorf("unexpected single condition: %s", v)
+	}
+
+	options := NewCpsOptions().
+		OrderBy("s2", true).
+		TopMax("s2", 100).
+		SectorDate(time.Date(2018, 2, 5, 0, 0, 0, 0, time.Local))
+
+	if v := options.OptionString(); v != "orderby=rd([s2]),top=max([s2],100),sectordate=2018-02-05" {
+		t.Errorf("unexpected options: %s", v)
+	}
+}
@@ -0,0 +1,69 @@
+package choice4go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCscOptionsString(t *testing.T) {
+	for name, c := range map[string]struct {
+		opt    *cscOptions
+		str    string
+		option string
+	}{
+		"default": {
+			opt:    NewCscOptions(),
+			str:    "CscOptions{BarSize:1 Adjust:不复权}",
+			option: "",
+		},
+		"bar size": {
+			opt:    NewCscOptions().BarSize(Bar5Min).BarSize(Bar60Min),
+			str:    "CscOptions{BarSize:60 Adjust:不复权}",
+			option: "Period=60",
+		},
+		"adjust": {
+			opt:    NewCscOptions().Adjust(ForwardAdjusted).BarSize(Bar15Min).Adjust(BackwordAdjusted),
+			str:    "CscOptions{BarSize:15 Adjust:后复权}",
+			option: "AdjustFlag=2,Period=15",
+		},
+	} {
+		if got := c.opt.String(); got != c.str {
+			t.Errorf("%s: String()\nexpected %s\ngot      %s", name, c.str, got)
+		}
+
+		if got := c.opt.OptionString(); got != c.option {
+			t.Errorf("%s: OptionString()\nexpected %s\ngot      %s", name, c.option, got)
+		}
+	}
+
+	if v := Bar30Min.String(); v != "30分钟" {
+		t.Errorf("unexpected bar size string: %s", v)
+	}
+
+	if v := barSize(2).String(); v != "barSize(2)" {
+		t.Errorf("unexpected unknown bar size string: %s", v)
+	}
+}
+
+func TestMinuteArgs(t *testing.T) {
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.Local)
+	end := time.Date(2024, 1, 2, 15, 0, 0, 0, time.Local)
+
+	for _, name := range []string{"csc", "cmc", "chmc"} {
+		startStr, endStr, err := minuteArgs(name, "300059.SZ", start, end)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if startStr != "2024-01-02 09:30:00" || endStr != "2024-01-02 15:00:00" {
+			t.Errorf("%s: unexpected time range: %s ~ %s", name, startStr, endStr)
+		}
+
+		for _, code := range []string{"", "300059.SZ,600000.SH"} {
+			if _, _, err := minuteArgs(name, code, start, end); !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("%s: code %q should be rejected, got %v", name, code, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package choice4go
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type asyncHandler interface {
+	onMessage(msg *EQMsg)
+}
+
+type MsgHandler func(msg *EQMsg)
+
+func (fn MsgHandler) onMessage(msg *EQMsg) {
+	fn(msg)
+}
+
+type dispatchEntry struct {
+	handler   asyncHandler
+	serialID  int
+	requestID int
+	// notice 接收无归属的订阅状态通知
+	notice bool
+}
+
+// dispatcher 将SDK异步回调消息路由至发起请求的订阅者
+//
+// 路由优先级: lpUserParam token > serialID > requestID > 订阅状态通知广播 > 全局监听
+//
+// 携带错误码的消息在路由前会先投递至全部监控者
+type dispatcher struct {
+	seq atomic.Uintptr
+
+	lock      sync.RWMutex
+	entries   map[uintptr]*dispatchEntry
+	serials   map[int]uintptr
+	requests  map[int]uintptr
+	listeners map[uintptr]asyncHandler
+	monitors  map[uintptr]asyncHandler
+}
+
+var msgDispatcher = newDispatcher()
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{
+		entries:   make(map[uintptr]*dispatchEntry),
+		serials:   make(map[int]uintptr),
+		requests:  make(map[int]uintptr),
+		listeners: make(map[uintptr]asyncHandler),
+		monitors:  make(map[uintptr]asyncHandler),
+	}
+}
+
+// register 注册异步回调处理器, 返回的token作为lpUserParam传入SDK
+func (d *dispatcher) register(h asyncHandler) uintptr {
+	return d.addEntry(&dispatchEntry{handler: h})
+}
+
+// registerSubscriber 同register, 并接收未携带token的订阅状态通知(如重连及权限验证失败)
+func (d *dispatcher) registerSubscriber(h asyncHandler) uintptr {
+	return d.addEntry(&dispatchEntry{handler: h, notice: true})
+}
+
+func (d *dispatcher) addEntry(entry *dispatchEntry) uintptr {
+	token := d.seq.Add(1)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.entries[token] = entry
+
+	return token
+}
+
+func (d *dispatcher) bindSerial(token uintptr, serialID int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	entry, exist := d.entries[token]
+	if !exist {
+		return
+	}
+
+	if entry.serialID != 0 {
+		delete(d.serials, entry.serialID)
+	}
+
+	entry.serialID = serialID
+	d.serials[serialID] = token
+}
+
+func (d *dispatcher) bindRequest(token uintptr, requestID int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	entry, exist := d.entries[token]
+	if !exist {
+		return
+	}
+
+	if entry.requestID != 0 {
+		delete(d.requests, entry.requestID)
+	}
+
+	entry.requestID = requestID
+	d.requests[requestID] = token
+}
+
+func (d *dispatcher) unregister(token uintptr) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	entry, exist := d.entries[token]
+	if !exist {
+		return
+	}
+
+	if entry.serialID != 0 && d.serials[entry.serialID] == token {
+		delete(d.serials, entry.serialID)
+	}
+
+	if entry.requestID != 0 && d.requests[entry.requestID] == token {
+		delete(d.requests, entry.requestID)
+	}
+
+	delete(d.entries, token)
+}
+
+func (d *dispatcher) listen(h asyncHandler) func() {
+	return d.addHandler(d.listeners, h)
+}
+
+// monitor 监控全部携带错误码的消息, 无论其是否被请求认领
+func (d *dispatcher) monitor(h asyncHandler) func() {
+	return d.addHandler(d.monitors, h)
+}
+
+func (d *dispatcher) addHandler(
+	handlers map[uintptr]asyncHandler, h asyncHandler,
+) func() {
+	token := d.seq.Add(1)
+
+	d.lock.Lock()
+	handlers[token] = h
+	d.lock.Unlock()
+
+	return func() {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+
+		delete(handlers, token)
+	}
+}
+
+func (d *dispatcher) handlers(handlers map[uintptr]asyncHandler) []asyncHandler {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	results := make([]asyncHandler, 0, len(handlers))
+	for _, h := range handlers {
+		results = append(results, h)
+	}
+
+	return results
+}
+
+// noticeSubscribers 返回全部接收订阅状态通知的处理器
+func (d *dispatcher) noticeSubscribers() []asyncHandler {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	var results []asyncHandler
+	for _, entry := range d.entries {
+		if entry.notice {
+			results = append(results, entry.handler)
+		}
+	}
+
+	return results
+}
+
+// isSubscriptionNotice 判断消息是否为不归属于特定请求的订阅状态通知
+func isSubscriptionNotice(token uintptr, msg *EQMsg) bool {
+	return token == 0 && msg.ErrCode != 0 &&
+		subscriptionEventKind(msg.ErrCode) != EventError
+}
+
+func (d *dispatcher) lookup(token uintptr, msg *EQMsg) (asyncHandler, bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if token == 0 {
+		if msg.SerialID != 0 {
+			token = d.serials[msg.SerialID]
+		}
+
+		if token == 0 && msg.RequestID != 0 {
+			token = d.requests[msg.RequestID]
+		}
+	}
+
+	if entry, exist := d.entries[token]; exist {
+		return entry.handler, true
+	}
+
+	return nil, false
+}
+
+// dispatch 分发消息, 无匹配订阅者时分发至全局监听并返回false
+func (d *dispatcher) dispatch(token uintptr, msg *EQMsg) bool {
+	if msg.ErrCode != 0 {
+		for _, h := range d.handlers(d.monitors) {
+			h.onMessage(msg)
+		}
+	}
+
+	if handler, ok := d.lookup(token, msg); ok {
+		handler.onMessage(msg)
+
+		return true
+	}
+
+	if isSubscriptionNotice(token, msg) {
+		if subscribers := d.noticeSubscribers(); len(subscribers) > 0 {
+			for _, h := range subscribers {
+				h.onMessage(msg)
+			}
+
+			return true
+		}
+	}
+
+	for _, h := range d.handlers(d.listeners) {
+		h.onMessage(msg)
+	}
+
+	return false
+}
+
+// OnMessage 监听未被任何请求认领的回调消息(如账号掉线通知), 返回取消监听函数
+func (ins *Choice) OnMessage(handler MsgHandler) func() {
+	return msgDispatcher.listen(handler)
+}
@@ -0,0 +1,113 @@
+package choice4go
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDispatcherRoute(t *testing.T) {
+	d := newDispatcher()
+
+	var byToken, bySerial, byListener int
+
+	token := d.register(MsgHandler(func(msg *EQMsg) { byToken++ }))
+	serialToken := d.register(MsgHandler(func(msg *EQMsg) { bySerial++ }))
+	d.bindSerial(serialToken, 100)
+
+	stop := d.listen(MsgHandler(func(msg *EQMsg) { byListener++ }))
+
+	if !d.dispatch(token, &EQMsg{}) {
+		t.Fatal("message with token not routed")
+	}
+
+	if !d.dispatch(0, &EQMsg{SerialID: 100}) {
+		t.Fatal("message with serial id not routed")
+	}
+
+	if d.dispatch(0, &EQMsg{SerialID: 200}) {
+		t.Fatal("unknown serial id routed")
+	}
+
+	d.unregister(serialToken)
+	if d.dispatch(0, &EQMsg{SerialID: 100}) {
+		t.Fatal("unregistered serial id routed")
+	}
+
+	stop()
+	d.dispatch(0, &EQMsg{})
+
+	if byToken != 1 || bySerial != 1 || byListener != 2 {
+		t.Fatalf(
+			"unexpected dispatch count: token[%d] serial[%d] listener[%d]",
+			byToken, bySerial, byListener,
+		)
+	}
+}
+
+func TestDispatcherMonitor(t *testing.T) {
+	d := newDispatcher()
+
+	var monitored, routed int
+
+	token := d.register(MsgHandler(func(msg *EQMsg) { routed++ }))
+	stop := d.monitor(MsgHandler(func(msg *EQMsg) { monitored++ }))
+
+	d.dispatch(token, &EQMsg{})
+	d.dispatch(token, &EQMsg{ErrCode: 10001011})
+	d.dispatch(0, &EQMsg{ErrCode: 10001011})
+
+	stop()
+	d.dispatch(token, &EQMsg{ErrCode: 10001011})
+
+	if monitored != 2 || routed != 3 {
+		t.Fatalf(
+			"unexpected dispatch count: monitored[%d] routed[%d]",
+			monitored, routed,
+		)
+	}
+}
+
+func TestDispatcherNoticeBroadcast(t *testing.T) {
+	d := newDispatcher()
+
+	var subscribers, requests, listened int
+
+	d.registerSubscriber(MsgHandler(func(msg *EQMsg) { subscribers++ }))
+	d.registerSubscriber(MsgHandler(func(msg *EQMsg) { subscribers++ }))
+	d.register(MsgHandler(func(msg *EQMsg) { requests++ }))
+	d.listen(MsgHandler(func(msg *EQMsg) { listened++ }))
+
+	if !d.dispatch(0, &EQMsg{ErrCode: int(EQERR_QUOTE_RECONNECT)}) {
+		t.Fatal("subscription notice not broadcast")
+	}
+
+	if d.dispatch(0, &EQMsg{ErrCode: int(EQERR_LOGIN_DISCONNECT)}) {
+		t.Fatal("account notice should not be broadcast to subscriptions")
+	}
+
+	if subscribers != 2 || requests != 0 || listened != 1 {
+		t.Fatalf(
+			"unexpected dispatch count: subscribers[%d] requests[%d] listened[%d]",
+			subscribers, requests, listened,
+		)
+	}
+}
+
+func TestSubscriptionNoticeEvent(t *testing.T) {
+	sub := newSubscription(context.Background(), &Choice{}, convertQuotes)
+	defer sub.Cancel()
+
+	msgDispatcher.dispatch(0, &EQMsg{
+		ErrCode: int(EQERR_QUOTE_RECONNECT),
+		Err:     &EQError{Code: EQERR_QUOTE_RECONNECT},
+	})
+
+	select {
+	case event := <-sub.Events():
+		if event.Kind != EventReconnecting {
+			t.Fatalf("unexpected event kind: %s", event.Kind)
+		}
+	default:
+		t.Fatal("token 0 notice not delivered to subscription events")
+	}
+}
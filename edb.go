@@ -0,0 +1,51 @@
+package choice4go
+
+import (
+	"fmt"
+	"time"
+)
+
+// EdbRow 宏观指标序列数据点
+type EdbRow struct {
+	ID    string
+	Date  time.Time
+	Value *EQValue
+
+	// 持有原始数据引用, 避免EQValue在行数据使用期间被回收至对象池
+	owner *EQData
+}
+
+func (v EdbRow) String() string {
+	return fmt.Sprintf(
+		"{ID:%s Date:%s Value:%+v}",
+		v.ID, v.Date.Format("2006-01-02"), v.Value.GetValue(),
+	)
+}
+
+// EdbData 宏观指标数据, 按(指标ID, 日期, 值)行迭代
+type EdbData struct {
+	data *EQData
+}
+
+func (edb *EdbData) Data() *EQData {
+	return edb.data
+}
+
+func (edb *EdbData) Iter() func(yield func(int, EdbRow) bool) {
+	return func(yield func(int, EdbRow) bool) {
+		for rowIdx, row := range edb.data.Iter() {
+			if len(row.value) == 0 {
+				continue
+			}
+
+			if !yield(rowIdx, EdbRow{
+				ID:    row.Code,
+				Date:  row.Date,
+				Value: row.value[0],
+				owner: edb.data,
+			}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package choice4go
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEdbOptionsString(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 6, 30, 0, 0, 0, 0, time.Local)
+
+	for name, c := range map[string]struct {
+		opt    *edbOptions
+		str    string
+		option string
+	}{
+		"empty": {
+			opt:    NewEdbOptions(),
+			str:    "EdbOptions{Start:0001-01-01 End:0001-01-01 Latest:false Frequency:edbFrequency(0)}",
+			option: "",
+		},
+		"date range": {
+			opt:    NewEdbOptions().DateRange(start, end),
+			str:    "EdbOptions{Start:2024-01-01 End:2024-06-30 Latest:false Frequency:edbFrequency(0)}",
+			option: "StartDate=2024-01-01,EndDate=2024-06-30",
+		},
+		"latest quarterly": {
+			opt:    NewEdbOptions().Latest().Frequency(EdbMonthly).Frequency(EdbQuarterly),
+			str:    "EdbOptions{Start:0001-01-01 End:0001-01-01 Latest:true Frequency:季}",
+			option: "IsLatest=1,Period=6",
+		},
+	} {
+		if got := c.opt.String(); got != c.str {
+			t.Errorf("%s: String()\nexpected %s\ngot      %s", name, c.str, got)
+		}
+
+		if got := c.opt.OptionString(); got != c.option {
+			t.Errorf("%s: OptionString()\nexpected %s\ngot      %s", name, c.option, got)
+		}
+	}
+}
+
+func TestEdbDataIter(t *testing.T) {
+	data := newTestEQData(
+		[]string{"EMM00087117", "EMG00147350"}, []string{"VALUE"},
+		[]string{"2024/1/31", "2024/2/29"},
+		0.3, 49.2,
+		0.7, 49.1,
+	)
+	data.values[3].valueType = ValueNull
+
+	type row struct {
+		id    string
+		date  string
+		value any
+	}
+
+	expected := []row{
+		{"EMM00087117", "2024-01-31", 0.3},
+		{"EMG00147350", "2024-01-31", 49.2},
+		{"EMM00087117", "2024-02-29", 0.7},
+		{"EMG00147350", "2024-02-29", nil},
+	}
+
+	var got []row
+	for _, r := range (&EdbData{data: data}).Iter() {
+		got = append(got, row{r.ID, r.Date.Format("2006-01-02"), r.Value.GetValue()})
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("unexpected row count: %d", len(got))
+	}
+
+	for idx := range expected {
+		if got[idx] != expected[idx] {
+			t.Errorf("row[%d]: expected %+v, got %+v", idx, expected[idx], got[idx])
+		}
+	}
+}
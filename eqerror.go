@@ -0,0 +1,209 @@
+package choice4go
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EQErr SDK返回的错误码, 与EmQuantAPI.h中EQERR_*定义一致
+type EQErr int
+
+const (
+	EQERR_SUCCESS      EQErr = 0                 // 成功
+	EQERR_BASE         EQErr = 10000000          // 错误基数
+	EQERR_BASE_GENERAL EQErr = EQERR_BASE        // 一般性错误
+	EQERR_BASE_ACCOUT  EQErr = EQERR_BASE + 1000 // 账户相关错误
+	EQERR_BASE_NET     EQErr = EQERR_BASE + 2000 // 网络相关错误
+	EQERR_BASE_PARAM   EQErr = EQERR_BASE + 3000 // 参数或请求错误
+
+	// 账户相关错误
+	EQERR_NO_LOGIN                EQErr = EQERR_BASE_ACCOUT + 1  // 用户未登录
+	EQERR_USERNAMEORPASSWORD_ERR  EQErr = EQERR_BASE_ACCOUT + 2  // 用户名或密码错误
+	EQERR_NO_ACCESS               EQErr = EQERR_BASE_ACCOUT + 3  // 用户无API权限
+	EQERR_ACCESS_EXPIRE           EQErr = EQERR_BASE_ACCOUT + 4  // 用户API权限过期
+	EQERR_GETUSERINFO_FAIL        EQErr = EQERR_BASE_ACCOUT + 5  // 获取用户信息失败
+	EQERR_DLLVESION_EXPIRE        EQErr = EQERR_BASE_ACCOUT + 6  // DLL版本号过期
+	EQERR_NO_LV2_ACCESS           EQErr = EQERR_BASE_ACCOUT + 7  // 用户无API_LV2权限
+	EQERR_LV2_ACCESS_EXPIRE       EQErr = EQERR_BASE_ACCOUT + 8  // 用户API_LV2权限过期
+	EQERR_LOGIN_COUNT_LIMIT       EQErr = EQERR_BASE_ACCOUT + 9  // 账号登录数达到上限
+	EQERR_LOGIN_FAIL              EQErr = EQERR_BASE_ACCOUT + 10 // 用户登录失败
+	EQERR_LOGIN_DISCONNECT        EQErr = EQERR_BASE_ACCOUT + 11 // 用户登录掉线
+	EQERR_ACCESS_INSUFFICIENCE    EQErr = EQERR_BASE_ACCOUT + 12 // 用户权限不足
+	EQERR_IS_LOGIN                EQErr = EQERR_BASE_ACCOUT + 13 // 用户正在登录
+	EQERR_NEED_ACTIVATE           EQErr = EQERR_BASE_ACCOUT + 14 // 需要登录激活
+	EQERR_LOGIN_SERVICE_ERR       EQErr = EQERR_BASE_ACCOUT + 15 // 登录服务异常
+	EQERR_IS_MANUAL_ACTIVATE      EQErr = EQERR_BASE_ACCOUT + 16 // 正在人工激活
+	EQERR_NOTNEED_MANUAL_ACTIVATE EQErr = EQERR_BASE_ACCOUT + 17 // 无需人工激活
+	EQERR_MANUAL_ACTIVATE_FAIL    EQErr = EQERR_BASE_ACCOUT + 18 // 人工激活失败
+	EQERR_DIFFRENT_DEVICE         EQErr = EQERR_BASE_ACCOUT + 19 // 激活设备与登录设备不一致
+	EQERR_USERINFO_EXPIRED        EQErr = EQERR_BASE_ACCOUT + 20 // userInfo已失效需重新激活
+	EQERR_QUOTE_LOGIN_FAIL        EQErr = EQERR_BASE_ACCOUT + 21 // 行情服务登录验证失败
+	EQERR_QUOTE_FLOW_FAIL         EQErr = EQERR_BASE_ACCOUT + 22 // 行情服务流量验证失败
+	EQERR_INFOQUERY_LOGIN_FAIL    EQErr = EQERR_BASE_ACCOUT + 23 // 资讯查询服务登录验证失败
+	EQERR_INFOSUB_LOGIN_FAIL      EQErr = EQERR_BASE_ACCOUT + 24 // 资讯订阅服务登录验证失败
+	EQERR_INFO_FLOW_FAIL          EQErr = EQERR_BASE_ACCOUT + 25 // 资讯服务流量验证失败
+	EQERR_SMS_INVALIED            EQErr = EQERR_BASE_ACCOUT + 26 // 无效的上行短信
+	EQERR_CHQQUOTE_LOGIN_FAIL     EQErr = EQERR_BASE_ACCOUT + 27 // 专项服务登录验证失败
+	EQERR_CHQQUOTE_ACCESS_FAIL    EQErr = EQERR_BASE_ACCOUT + 28 // 专项服务权限验证失败
+
+	// 一般性错误
+	EQERR_GET_TRADE_FAIL         EQErr = EQERR_BASE_GENERAL + 1  // 获取交易日失败
+	EQERR_INIT_OBTAIN_CLASS_FAIL EQErr = EQERR_BASE_GENERAL + 2  // 初始化主类失败
+	EQERR_NEW_MEM_FAIL           EQErr = EQERR_BASE_GENERAL + 3  // 申请内存失败
+	EQERR_PARSE_DATA_ERR         EQErr = EQERR_BASE_GENERAL + 4  // 解析数据错误
+	EQERR_UNGZIP_DATA_FAIL       EQErr = EQERR_BASE_GENERAL + 5  // gzip解压失败
+	EQERR_UNKNOWN_ERR            EQErr = EQERR_BASE_GENERAL + 6  // 未知错误
+	EQERR_FUNCTION_INTERNAL_ERR  EQErr = EQERR_BASE_GENERAL + 7  // 函数内部错误
+	EQERR_OUTOF_BOUNDS           EQErr = EQERR_BASE_GENERAL + 8  // 数组越界
+	EQERR_NO_DATA                EQErr = EQERR_BASE_GENERAL + 9  // 无数据
+	EQERR_SYSTEM_ERROR           EQErr = EQERR_BASE_GENERAL + 10 // 系统级别错误
+	EQERR_SERVERLIST_ERROR       EQErr = EQERR_BASE_GENERAL + 11 // 服务器列表错误
+	EQERR_OPERATION_FAILURE      EQErr = EQERR_BASE_GENERAL + 12 // 操作失败
+	EQERR_SERVICE_ERROR          EQErr = EQERR_BASE_GENERAL + 13 // 服务出错
+	EQERR_GETSERVERLIST_FAIL     EQErr = EQERR_BASE_GENERAL + 14 // 获取服务器列表失败
+	EQERR_SERVICE_TIMEOUT        EQErr = EQERR_BASE_GENERAL + 15 // 服务超时
+	EQERR_FREQUENCY_OVER         EQErr = EQERR_BASE_GENERAL + 16 // 请求频次过高
+	EQERR_OVERSEAS_IP_RESTRICTED EQErr = EQERR_BASE_GENERAL + 17 // 海外IP受限
+	EQERR_POP_GROUP_NOT_SUPPORT  EQErr = EQERR_BASE_GENERAL + 18 // POP组合不支持此操作
+
+	// 网络相关错误
+	EQERR_SOCKET_ERR              EQErr = EQERR_BASE_NET + 1  // 网络错误
+	EQERR_CONNECT_FAIL            EQErr = EQERR_BASE_NET + 2  // 网络连接失败
+	EQERR_CONNECT_TIMEOUT         EQErr = EQERR_BASE_NET + 3  // 网络连接超时
+	EQERR_RECVCONNECTION_CLOSED   EQErr = EQERR_BASE_NET + 4  // 网络接收时连接断开
+	EQERR_SENDSOCK_FAIL           EQErr = EQERR_BASE_NET + 5  // 网络发送失败
+	EQERR_SENDSOCK_TIMEOUT        EQErr = EQERR_BASE_NET + 6  // 网络发送超时
+	EQERR_RECVSOCK_FAIL           EQErr = EQERR_BASE_NET + 7  // 网络接收错误
+	EQERR_RECVSOCK_TIMEOUT        EQErr = EQERR_BASE_NET + 8  // 网络接收超时
+	EQERR_QUOTE_RECONNECT_FAIL    EQErr = EQERR_BASE_NET + 9  // 行情服务器连续重连失败
+	EQERR_HTTP_FAIL               EQErr = EQERR_BASE_NET + 10 // http访问失败
+	EQERR_WAIT_NET_RES_TIMEOUT    EQErr = EQERR_BASE_NET + 11 // 等待网络响应超时
+	EQERR_QUOTE_RECONNECT         EQErr = EQERR_BASE_NET + 12 // 行情服务器重连
+	EQERR_INFO_RECONNECT          EQErr = EQERR_BASE_NET + 13 // 资讯服务器重连
+	EQERR_INFO_RECONNECT_FAIL     EQErr = EQERR_BASE_NET + 14 // 资讯服务器连续重连失败
+	EQERR_CHQQUOTE_RECONNECT_FAIL EQErr = EQERR_BASE_NET + 15 // 专项服务器连续重连失败
+	EQERR_CHQQUOTE_RECONNECT      EQErr = EQERR_BASE_NET + 16 // 专项服务器重连
+
+	// 参数或请求错误
+	EQERR_INPARAM_EMPTY            EQErr = EQERR_BASE_PARAM + 1  // 传入参数为空
+	EQERR_OUTPARAM_EMPTY           EQErr = EQERR_BASE_PARAM + 2  // 传出参数为空
+	EQERR_PARAM_ERR                EQErr = EQERR_BASE_PARAM + 3  // 参数错误
+	EQERR_START_DATE_ERR           EQErr = EQERR_BASE_PARAM + 4  // 起始日期格式不正确
+	EQERR_END_DATE_ERR             EQErr = EQERR_BASE_PARAM + 5  // 截止日期格式不正确
+	EQERR_START_BIGTHAN_END        EQErr = EQERR_BASE_PARAM + 6  // 起始日期大于截至日期
+	EQERR_DATE_ERR                 EQErr = EQERR_BASE_PARAM + 7  // 日期格式不正确
+	EQERR_CODE_INVALIED            EQErr = EQERR_BASE_PARAM + 8  // 无效的证券代码
+	EQERR_CODE_REPEAT              EQErr = EQERR_BASE_PARAM + 9  // 证券代码重复
+	EQERR_INDICATOR_INVALIED       EQErr = EQERR_BASE_PARAM + 10 // 无效的指标
+	EQERR_USERNAME_EMPTY           EQErr = EQERR_BASE_PARAM + 11 // 用户名为空
+	EQERR_PASSWORD_EMPTY           EQErr = EQERR_BASE_PARAM + 12 // 密码为空
+	EQERR_TO_UPPER_LIMIT           EQErr = EQERR_BASE_PARAM + 13 // 订阅数或股票总数达到上限
+	EQERR_MIXED_INDICATOR          EQErr = EQERR_BASE_PARAM + 14 // 不支持的混合指标
+	EQERR_INDICATOR_TO_UPPER_LIMIT EQErr = EQERR_BASE_PARAM + 15 // 单次订阅指标达到上限
+	EQERR_BEYOND_DATE_SUPPORT      EQErr = EQERR_BASE_PARAM + 16 // 超出日期支持范围
+	EQERR_BASE_LESS_THAN_END       EQErr = EQERR_BASE_PARAM + 17 // 复权基期小于截止日期
+	EQERR_MIXED_CODES_MARKET       EQErr = EQERR_BASE_PARAM + 18 // 不支持的混合证券品种
+	EQERR_NO_SUPPORT_CODES_MARKET  EQErr = EQERR_BASE_PARAM + 19 // 不支持的证券代码品种
+	EQERR_ORDER_TO_UPPER_LIMIT     EQErr = EQERR_BASE_PARAM + 20 // 交易条数超过上限
+	EQERR_NO_SUPPORT_ORDERINFO     EQErr = EQERR_BASE_PARAM + 21 // 不支持的交易信息
+	EQERR_INDICATOR_REPEAT         EQErr = EQERR_BASE_PARAM + 22 // 指标重复
+	EQERR_INFOBKCODE_INVALIED      EQErr = EQERR_BASE_PARAM + 23 // 资讯板块代码错误
+	EQERR_INFOSIZE_TOOLARGE        EQErr = EQERR_BASE_PARAM + 24 // 资讯数据量过大
+	EQERR_INFO_SEARCH_NODATA       EQErr = EQERR_BASE_PARAM + 25 // 资讯查询不到数据
+	EQERR_INFOBKCODE_REPEAT        EQErr = EQERR_BASE_PARAM + 26 // 资讯板块代码重复
+)
+
+// 错误码分类, 按EmQuantAPI.h中的错误基数划分
+var (
+	ErrGeneral = errors.New("choice general error")
+	ErrAccount = errors.New("choice account error")
+	ErrNetwork = errors.New("choice network error")
+	ErrParam   = errors.New("choice param error")
+)
+
+// 常用错误码哨兵
+var (
+	ErrNoData            = errors.New("choice no data")
+	ErrRateLimited       = errors.New("choice request frequency over limit")
+	ErrTimeout           = errors.New("choice request timeout")
+	ErrNotLogin          = errors.New("choice user not login")
+	ErrLoginDisconnected = errors.New("choice login disconnected")
+	ErrAuthFailed        = errors.New("choice username or password error")
+	ErrPermission        = errors.New("choice permission denied")
+)
+
+var codeSentinels = map[EQErr]error{
+	EQERR_NO_DATA:            ErrNoData,
+	EQERR_INFO_SEARCH_NODATA: ErrNoData,
+
+	EQERR_FREQUENCY_OVER: ErrRateLimited,
+
+	EQERR_SERVICE_TIMEOUT:      ErrTimeout,
+	EQERR_CONNECT_TIMEOUT:      ErrTimeout,
+	EQERR_SENDSOCK_TIMEOUT:     ErrTimeout,
+	EQERR_RECVSOCK_TIMEOUT:     ErrTimeout,
+	EQERR_WAIT_NET_RES_TIMEOUT: ErrTimeout,
+
+	EQERR_NO_LOGIN:               ErrNotLogin,
+	EQERR_LOGIN_DISCONNECT:       ErrLoginDisconnected,
+	EQERR_USERNAMEORPASSWORD_ERR: ErrAuthFailed,
+
+	EQERR_NO_ACCESS:            ErrPermission,
+	EQERR_ACCESS_EXPIRE:        ErrPermission,
+	EQERR_NO_LV2_ACCESS:        ErrPermission,
+	EQERR_LV2_ACCESS_EXPIRE:    ErrPermission,
+	EQERR_ACCESS_INSUFFICIENCE: ErrPermission,
+	EQERR_QUOTE_FLOW_FAIL:      ErrPermission,
+	EQERR_INFO_FLOW_FAIL:       ErrPermission,
+	EQERR_CHQQUOTE_ACCESS_FAIL: ErrPermission,
+
+	EQERR_NEED_ACTIVATE:    ErrNeedActivate,
+	EQERR_USERINFO_EXPIRED: ErrNeedActivate,
+	EQERR_DIFFRENT_DEVICE:  ErrNeedActivate,
+}
+
+// Category 返回错误码所属分类, 非SDK错误码返回nil
+func (code EQErr) Category() error {
+	switch {
+	case code >= EQERR_BASE_PARAM && code < EQERR_BASE_PARAM+1000:
+		return ErrParam
+	case code >= EQERR_BASE_NET && code < EQERR_BASE_PARAM:
+		return ErrNetwork
+	case code >= EQERR_BASE_ACCOUT && code < EQERR_BASE_NET:
+		return ErrAccount
+	case code >= EQERR_BASE_GENERAL && code < EQERR_BASE_ACCOUT:
+		return ErrGeneral
+	default:
+		return nil
+	}
+}
+
+// EQError SDK调用错误, 支持errors.Is匹配ErrEQCall、错误分类及常用错误码哨兵
+type EQError struct {
+	Code EQErr
+	Msg  string
+}
+
+func (e *EQError) Error() string {
+	return fmt.Sprintf("%s: [%d] %s", ErrEQCall, e.Code, e.Msg)
+}
+
+func (e *EQError) Is(target error) bool {
+	if t, ok := target.(*EQError); ok {
+		return t.Code == e.Code
+	}
+
+	if target == ErrEQCall {
+		return true
+	}
+
+	if category := e.Code.Category(); category != nil && target == category {
+		return true
+	}
+
+	if sentinel, exist := codeSentinels[e.Code]; exist && target == sentinel {
+		return true
+	}
+
+	return false
+}
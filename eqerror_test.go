@@ -0,0 +1,41 @@
+package choice4go
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestEQErrorIs(t *testing.T) {
+	for _, c := range []struct {
+		code    EQErr
+		targets []error
+		not     []error
+	}{
+		{EQERR_FREQUENCY_OVER, []error{ErrEQCall, ErrGeneral, ErrRateLimited}, []error{ErrNetwork, ErrNoData}},
+		{EQERR_NO_DATA, []error{ErrGeneral, ErrNoData}, []error{ErrAccount}},
+		{EQERR_LOGIN_DISCONNECT, []error{ErrAccount, ErrLoginDisconnected}, []error{ErrPermission}},
+		{EQERR_NEED_ACTIVATE, []error{ErrAccount, ErrNeedActivate}, []error{ErrParam}},
+		{EQERR_RECVSOCK_TIMEOUT, []error{ErrNetwork, ErrTimeout}, []error{ErrGeneral}},
+		{EQERR_CODE_INVALIED, []error{ErrParam}, []error{ErrNetwork, ErrTimeout}},
+	} {
+		err := fmt.Errorf("wrapped: %w", &EQError{Code: c.code})
+
+		for _, target := range c.targets {
+			if !errors.Is(err, target) {
+				t.Errorf("code[%d] should match %v", c.code, target)
+			}
+		}
+
+		for _, target := range c.not {
+			if errors.Is(err, target) {
+				t.Errorf("code[%d] should not match %v", c.code, target)
+			}
+		}
+
+		var eqErr *EQError
+		if !errors.As(err, &eqErr) || eqErr.Code != c.code {
+			t.Errorf("code[%d] errors.As failed", c.code)
+		}
+	}
+}
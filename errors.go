@@ -6,6 +6,7 @@ var (
 	ErrUnsupportedSys   = errors.New("unsupported system")
 	ErrLoadLib          = errors.New("load library failed")
 	ErrLoadFunc         = errors.New("load function failed")
+	ErrUnknownFunc      = errors.New("unknown data function call")
 	ErrInitialized      = errors.New("choice api not initialized")
 	ErrMainCbFailed     = errors.New("set main callback failed")
 	ErrDataEmpty        = errors.New("data is empty")
@@ -15,4 +16,9 @@ var (
 	ErrGetData          = errors.New("make data structure failed")
 	ErrEQCall           = errors.New("choice func call failed")
 	ErrInvalidArgs      = errors.New("choice func call with invalid args")
+	ErrAlreadyStarted   = errors.New("choice api already started")
+	ErrCfgPath          = errors.New("invalid choice cfg path")
+	ErrTokenMissing     = errors.New("choice token file missing")
+	ErrNeedActivate     = errors.New("choice device activation required")
+	ErrFieldMissing     = errors.New("choice data field missing")
 )
@@ -0,0 +1,71 @@
+package choice4go
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FanOutCodes 对仅支持单个代码的查询(如Csc/Cmc/Chmc)按代码并发执行, 并合并结果
+//
+// parallel <= 0 时按顺序逐个查询, 任一代码查询失败即返回错误.
+// 无数据的代码会被忽略.
+func FanOutCodes(
+	codes []string, parallel int,
+	query func(code string) (*EQData, error),
+) (*EQData, error) {
+	if len(codes) == 0 || query == nil {
+		return nil, fmt.Errorf(
+			"%w: codes or query func is empty", ErrInvalidArgs,
+		)
+	}
+
+	results, errs := queryParallel(len(codes), parallel, func(idx int) (*EQData, error) {
+		return query(codes[idx])
+	})
+
+	for idx, err := range errs {
+		if err == nil || errors.Is(err, ErrDataEmpty) {
+			continue
+		}
+
+		return nil, fmt.Errorf("query %s failed: %w", codes[idx], err)
+	}
+
+	return mergeEQData(results...)
+}
+
+// queryParallel 以最多parallel个并发执行count个查询, 结果按下标顺序返回
+func queryParallel(
+	count, parallel int,
+	query func(idx int) (*EQData, error),
+) ([]*EQData, []error) {
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, parallel)
+		results = make([]*EQData, count)
+		errs    = make([]error, count)
+	)
+
+	for idx := range count {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			results[idx], errs[idx] = query(idx)
+		}()
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
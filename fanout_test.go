@@ -0,0 +1,66 @@
+package choice4go
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFanOutCodes(t *testing.T) {
+	codes := []string{"000001.SZ", "600000.SH", "300059.SZ", "600519.SH"}
+
+	for _, parallel := range []int{0, 1, 3} {
+		merged, err := FanOutCodes(codes, parallel, func(code string) (*EQData, error) {
+			if code == codes[2] {
+				return nil, ErrDataEmpty
+			}
+
+			return newTestEQData(
+				[]string{code}, []string{"CLOSE"}, []string{"2024/1/2"},
+				float64(slices.Index(codes, code)),
+			), nil
+		})
+		if err != nil {
+			t.Fatalf("parallel[%d]: %v", parallel, err)
+		}
+
+		expected := []string{codes[0], codes[1], codes[3]}
+		if !slices.Equal(merged.codes, expected) {
+			t.Fatalf("parallel[%d]: codes order changed: %v", parallel, merged.codes)
+		}
+
+		for _, row := range merged.Iter() {
+			v, _ := row.Value("CLOSE")
+			if got, _ := v.AsInt64(); got != int64(slices.Index(codes, row.Code)) {
+				t.Errorf("parallel[%d] %s: unexpected value %v", parallel, row.Code, v.GetValue())
+			}
+		}
+	}
+}
+
+func TestFanOutCodesError(t *testing.T) {
+	codes := []string{"000001.SZ", "600000.SH"}
+
+	_, err := FanOutCodes(codes, 2, func(code string) (*EQData, error) {
+		if code == codes[1] {
+			return nil, ErrTimeout
+		}
+
+		return newTestEQData(
+			[]string{code}, []string{"CLOSE"}, []string{"2024/1/2"}, 1,
+		), nil
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected query error, got %v", err)
+	}
+
+	if _, err := FanOutCodes(nil, 1, func(string) (*EQData, error) {
+		return nil, nil
+	}); !errors.Is(err, ErrInvalidArgs) {
+		t.Fatalf("expected ErrInvalidArgs for empty codes, got %v", err)
+	}
+
+	if _, err := FanOutCodes(codes, 1, nil); !errors.Is(err, ErrInvalidArgs) {
+		t.Fatalf("expected ErrInvalidArgs for nil query, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package choice4go
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+	"unsafe"
+)
+
+// TestCheckLibFnNames 校验源码中所有checkLibFn调用的函数名均可被解析
+func TestCheckLibFnNames(t *testing.T) {
+	src, err := os.ReadFile("cgo_dynlib.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var placeholder byte
+	ins := &Choice{lib: unsafe.Pointer(&placeholder)}
+
+	names := regexp.MustCompile(`checkLibFn\("(\w+)"\)`).FindAllSubmatch(src, -1)
+	if len(names) == 0 {
+		t.Fatal("no checkLibFn call found")
+	}
+
+	for _, match := range names {
+		name := string(match[1])
+
+		if _, err := ins.checkLibFn(name); errors.Is(err, ErrUnknownFunc) {
+			t.Errorf("checkLibFn(%q) not resolvable: %v", name, err)
+		}
+	}
+
+	if _, err := ins.CSec([]string{"001004"}, []string{"NAME"}, nil); errors.Is(err, ErrUnknownFunc) {
+		t.Errorf("CSec lookup failed: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package choice4go
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+//go:generate stringer -type LifecycleState -linecomment
+type LifecycleState uint32
+
+// 生命周期: Loaded → Started → Stopped → Started ...
+const (
+	StateLoaded  LifecycleState = iota // 已加载
+	StateStarted                       // 已启动
+	StateStopped                       // 已停止
+)
+
+// State 返回当前生命周期状态
+func (ins *Choice) State() LifecycleState {
+	return LifecycleState(ins.state.Load())
+}
+
+func (ins *Choice) isStarted() bool {
+	return ins.State() == StateStarted
+}
+
+// rootDone 返回当前启动周期的结束通道, 未启动时返回nil
+func (ins *Choice) rootDone() <-chan struct{} {
+	ins.stateLock.RLock()
+	defer ins.stateLock.RUnlock()
+
+	if ins.rootCtx == nil {
+		return nil
+	}
+
+	return ins.rootCtx.Done()
+}
+
+// start 登录成功后进入Started状态, 登录期间不持有stateLock, 不阻塞rootDone
+func (ins *Choice) start(ctx context.Context, login func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ins.lifecycleLock.Lock()
+	defer ins.lifecycleLock.Unlock()
+
+	if ins.isStarted() {
+		slog.Warn("choice api already started")
+		return nil
+	}
+
+	if err := login(); err != nil {
+		return err
+	}
+
+	rootCtx, rootCancel := context.WithCancel(ctx)
+
+	ins.stateLock.Lock()
+	ins.rootCtx, ins.rootCancel = rootCtx, rootCancel
+	ins.stateLock.Unlock()
+
+	ins.state.Store(uint32(StateStarted))
+
+	return nil
+}
+
+// activate 后台执行人工激活, 与Start、Stop及relogin互斥
+//
+// ctx结束时仅停止等待, 已发起的SDK激活调用无法中断, 返回前后续的Activate及Start均会阻塞.
+func (ins *Choice) activate(ctx context.Context, run func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result := make(chan error, 1)
+
+	go func() {
+		ins.lifecycleLock.Lock()
+		defer ins.lifecycleLock.Unlock()
+
+		// 等待前一次激活期间ctx已结束, 不再发起激活
+		if err := ctx.Err(); err != nil {
+			result <- err
+			return
+		}
+
+		if ins.isStarted() {
+			result <- fmt.Errorf(
+				"%w: activate must be called before start", ErrAlreadyStarted,
+			)
+			return
+		}
+
+		result <- run()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-result:
+		return err
+	}
+}
+
+// stop 结束当前启动周期并退出登录
+func (ins *Choice) stop(logout func() error) error {
+	ins.lifecycleLock.Lock()
+	defer ins.lifecycleLock.Unlock()
+
+	if !ins.isStarted() {
+		slog.Warn("choice api not started")
+		return nil
+	}
+
+	ins.stateLock.RLock()
+	rootCancel := ins.rootCancel
+	ins.stateLock.RUnlock()
+
+	rootCancel()
+	ins.state.Store(uint32(StateStopped))
+
+	return logout()
+}
+
+// relogin 会话断线后重新登录, 期间与Start/Stop互斥
+func (ins *Choice) relogin(user, pass string, options Option) error {
+	return ins.restart(ins.logout, func() error {
+		return ins.login(user, pass, options)
+	})
+}
+
+// restart 保持当前启动周期, 依次退出并重新登录
+func (ins *Choice) restart(logout, login func() error) error {
+	ins.lifecycleLock.Lock()
+	defer ins.lifecycleLock.Unlock()
+
+	if !ins.isStarted() {
+		return fmt.Errorf(
+			"%w: choice api not started", ErrInitialized,
+		)
+	}
+
+	if err := logout(); err != nil {
+		slog.Warn(
+			"choice logout before relogin failed",
+			slog.Any("error", err),
+		)
+	}
+
+	return login()
+}
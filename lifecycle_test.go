@@ -0,0 +1,179 @@
+package choice4go
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLifecycleStartStopStart(t *testing.T) {
+	ins := &Choice{}
+
+	var logins, logouts int
+	login := func() error { logins++; return nil }
+	logout := func() error { logouts++; return nil }
+
+	if err := ins.start(context.Background(), login); err != nil {
+		t.Fatal(err)
+	}
+	firstDone := ins.rootDone()
+
+	if ins.State() != StateStarted {
+		t.Fatalf("expected started, got %s", ins.State())
+	}
+
+	// 重复启动不再登录
+	if err := ins.start(context.Background(), login); err != nil || logins != 1 {
+		t.Fatalf("duplicated start should be ignored: %v, logins[%d]", err, logins)
+	}
+
+	if err := ins.stop(logout); err != nil {
+		t.Fatal(err)
+	}
+
+	if ins.State() != StateStopped {
+		t.Fatalf("expected stopped, got %s", ins.State())
+	}
+
+	select {
+	case <-firstDone:
+	default:
+		t.Fatal("root ctx not canceled on stop")
+	}
+
+	if err := ins.restart(logout, login); !errors.Is(err, ErrInitialized) {
+		t.Fatalf("relogin after stop should fail, got %v", err)
+	}
+
+	if err := ins.start(context.Background(), login); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-ins.rootDone():
+		t.Fatal("restarted root ctx should be alive")
+	default:
+	}
+
+	if ins.State() != StateStarted || logins != 2 || logouts != 1 {
+		t.Fatalf(
+			"unexpected lifecycle: state[%s] logins[%d] logouts[%d]",
+			ins.State(), logins, logouts,
+		)
+	}
+}
+
+func TestLifecycleFailedStart(t *testing.T) {
+	ins := &Choice{}
+
+	if err := ins.start(context.Background(), func() error {
+		return ErrAuthFailed
+	}); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("expected login error, got %v", err)
+	}
+
+	if ins.isStarted() {
+		t.Fatal("failed start should not change state")
+	}
+
+	if err := ins.start(context.Background(), func() error {
+		return nil
+	}); err != nil || !ins.isStarted() {
+		t.Fatalf("start after failure should succeed: %v", err)
+	}
+}
+
+func TestLifecycleReloginNotBlockRootDone(t *testing.T) {
+	ins := &Choice{}
+
+	if err := ins.start(context.Background(), func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	finished := make(chan error, 1)
+
+	go func() {
+		finished <- ins.restart(
+			func() error { return nil },
+			func() error {
+				close(entered)
+				<-release
+				return nil
+			},
+		)
+	}()
+
+	<-entered
+
+	got := make(chan (<-chan struct{}), 1)
+	go func() { got <- ins.rootDone() }()
+
+	select {
+	case <-got:
+	case <-time.After(time.Second):
+		t.Fatal("rootDone blocked by relogin")
+	}
+
+	close(release)
+
+	if err := <-finished; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActivateError(t *testing.T) {
+	ins := &Choice{}
+
+	for _, code := range []int{0, int(EQERR_NOTNEED_MANUAL_ACTIVATE)} {
+		if err := ins.activateError(code); err != nil {
+			t.Fatalf("code[%d] should be treated as success, got %v", code, err)
+		}
+	}
+}
+
+func TestActivateSerialized(t *testing.T) {
+	ins := &Choice{}
+
+	entered, release := make(chan struct{}), make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-entered
+		cancel()
+	}()
+
+	// ctx结束仅停止等待, 激活调用仍在后台执行
+	if err := ins.activate(ctx, func() error {
+		close(entered)
+		<-release
+		return nil
+	}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+
+	started := make(chan error, 1)
+	go func() {
+		started <- ins.start(context.Background(), func() error { return nil })
+	}()
+
+	select {
+	case <-started:
+		t.Fatal("start should wait for running activation")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	if err := <-started; err != nil || !ins.isStarted() {
+		t.Fatalf("start after activation failed: %v", err)
+	}
+
+	if err := ins.activate(context.Background(), func() error {
+		t.Error("activate should not run after start")
+		return nil
+	}); !errors.Is(err, ErrAlreadyStarted) {
+		t.Fatalf("expected ErrAlreadyStarted, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package choice4go
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// RateLimitAll 对全部函数生效的全局限流名称
+const RateLimitAll = "*"
+
+// RateLimit 令牌桶限流参数, Rate为每秒请求数, Burst为允许的突发请求数
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// tokenBucket 令牌桶限流器, 令牌可预支, 预支部分按速率排队等待
+type tokenBucket struct {
+	lock   sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	limit.Burst = max(limit.Burst, 1)
+
+	return &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+	}
+}
+
+// reserve 取一个令牌, 返回取得令牌前需等待的时长
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens = min(
+			b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate,
+			float64(b.limit.Burst),
+		)
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+}
+
+// cancel 归还一个reserve取得的令牌, 用于限流等待被中止时
+func (b *tokenBucket) cancel() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.tokens = min(b.tokens+1, float64(b.limit.Burst))
+}
+
+// SetRateLimit 设置函数(如csd, css, cses)的请求频率限制, name为RateLimitAll时限制全部请求总频率
+//
+// limit为nil或Rate不大于0时取消该限制
+func (ins *Choice) SetRateLimit(name string, limit *RateLimit) {
+	if limit == nil || limit.Rate <= 0 {
+		ins.limiters.Delete(name)
+		return
+	}
+
+	ins.limiters.Store(name, newTokenBucket(*limit))
+}
+
+// throttle 同时从全局及函数限流取令牌并等待其中较长者, choice停止或ctx结束时归还令牌并返回错误
+func (ins *Choice) throttle(ctx context.Context, name string) error {
+	var (
+		now      = time.Now()
+		wait     time.Duration
+		limiter  string
+		reserved []*tokenBucket
+	)
+
+	for _, key := range []string{RateLimitAll, name} {
+		v, exist := ins.limiters.Load(key)
+		if !exist {
+			continue
+		}
+
+		bucket := v.(*tokenBucket)
+		reserved = append(reserved, bucket)
+
+		if w := bucket.reserve(now); w > wait {
+			wait, limiter = w, key
+		}
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+
+	slog.Debug(
+		"choice request throttled",
+		slog.String("func", name),
+		slog.String("limiter", limiter),
+		slog.Duration("wait", wait),
+	)
+
+	if ins.waitRetry(ctx, wait) {
+		return nil
+	}
+
+	for _, bucket := range reserved {
+		bucket.cancel()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: aborted while throttled", err)
+	}
+
+	return fmt.Errorf(
+		"%w: choice api stopped while throttled", ErrInitialized,
+	)
+}
+
+// invoke 经限流后执行SDK调用, 调用成功时按返回的数据点数记录用量
+//
+// 所有SDK请求(查询、订阅及组合操作)均应经由invoke发起.
+func (ins *Choice) invoke(
+	ctx context.Context, name string, call func() (cells int, err error),
+) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := ins.throttle(ctx, name); err != nil {
+		return err
+	}
+
+	cells, err := call()
+	if err != nil {
+		return err
+	}
+
+	ins.quota.record(name, cells)
+
+	return nil
+}
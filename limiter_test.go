@@ -0,0 +1,105 @@
+package choice4go
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	bucket := newTokenBucket(RateLimit{Rate: 2, Burst: 2})
+	now := time.Date(2024, 1, 2, 9, 30, 0, 0, time.Local)
+
+	for idx, expect := range []time.Duration{
+		0, 0, 500 * time.Millisecond, time.Second,
+	} {
+		if wait := bucket.reserve(now); wait != expect {
+			t.Fatalf("reserve[%d] wait %s, expect %s", idx, wait, expect)
+		}
+	}
+
+	// 2秒后补充4个令牌, 抵扣预支的2个后剩余2个
+	now = now.Add(2 * time.Second)
+	for idx := range 2 {
+		if wait := bucket.reserve(now); wait != 0 {
+			t.Fatalf("refilled reserve[%d] wait %s", idx, wait)
+		}
+	}
+
+	if wait := bucket.reserve(now); wait != 500*time.Millisecond {
+		t.Fatalf("reserve after refill wait %s", wait)
+	}
+}
+
+func TestQuotaMeter(t *testing.T) {
+	var meter quotaMeter
+
+	meter.record("csd", 12)
+	meter.record("csd", 8)
+	meter.record("css", 3)
+
+	usage := meter.snapshot()
+	if usage["csd"] != (QuotaUsage{Calls: 2, Cells: 20}) ||
+		usage["css"] != (QuotaUsage{Calls: 1, Cells: 3}) {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	meter.reset()
+	if usage := meter.snapshot(); len(usage) != 0 {
+		t.Fatalf("usage not reset: %+v", usage)
+	}
+}
+
+func TestThrottleAbortReturnsToken(t *testing.T) {
+	ins := &Choice{}
+	ins.SetRateLimit(RateLimitAll, &RateLimit{Rate: 0.001, Burst: 2})
+	ins.SetRateLimit("csd", &RateLimit{Rate: 0.001, Burst: 1})
+
+	if err := ins.throttle(context.Background(), "csd"); err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := ins.throttle(canceled, "csd"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled throttle, got %v", err)
+	}
+
+	// 被中止的请求已归还全局令牌, 其他函数无需等待
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := ins.throttle(ctx, "css"); err != nil {
+		t.Fatalf("global token not returned on abort: %v", err)
+	}
+}
+
+func TestInvokeRecordsUsage(t *testing.T) {
+	ins := &Choice{}
+
+	if err := ins.invoke(context.Background(), "csd", func() (int, error) {
+		return 6, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ins.invoke(context.Background(), "csd", func() (int, error) {
+		return 0, ErrNoData
+	}); !errors.Is(err, ErrNoData) {
+		t.Fatalf("expected call error, got %v", err)
+	}
+
+	if err := ins.invoke(context.Background(), "csq", func() (int, error) {
+		return 0, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	usage := ins.Usage()
+	if usage["csd"] != (QuotaUsage{Calls: 1, Cells: 6}) ||
+		usage["csq"] != (QuotaUsage{Calls: 1}) {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+}
@@ -0,0 +1,113 @@
+package choice4go
+
+import (
+	"runtime"
+	"slices"
+	"time"
+)
+
+func appendUnique(dst []string, idx map[string]int, src []string) []string {
+	for _, v := range src {
+		if _, exist := idx[v]; exist {
+			continue
+		}
+
+		idx[v] = len(dst)
+		dst = append(dst, v)
+	}
+
+	return dst
+}
+
+// mergeEQData 合并多个EQData为一个数据立方
+//
+// 证券和指标按首次出现顺序排列, 日期按时间先后排序, 缺失单元以Null值填充.
+// 合并结果中的EQValue均为拷贝, 与源数据的生命周期无关.
+func mergeEQData(parts ...*EQData) (*EQData, error) {
+	parts = slices.DeleteFunc(slices.Clone(parts), func(v *EQData) bool {
+		return v == nil
+	})
+
+	if len(parts) == 0 {
+		return nil, ErrDataEmpty
+	}
+
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	var (
+		codes, indicators, dates []string
+		codeIdx, indicatorIdx    = map[string]int{}, map[string]int{}
+		dateIdx                  = map[string]int{}
+		dateValues               = map[string]time.Time{}
+	)
+
+	for _, part := range parts {
+		codes = appendUnique(codes, codeIdx, part.codes)
+		indicators = appendUnique(indicators, indicatorIdx, part.indicators)
+		dates = appendUnique(dates, dateIdx, part.dateList)
+	}
+
+	for _, dateStr := range dates {
+		date, _ := parseEQDate(dateStr)
+		dateValues[dateStr] = date
+	}
+
+	slices.SortStableFunc(dates, func(a, b string) int {
+		if c := dateValues[a].Compare(dateValues[b]); c != 0 {
+			return c
+		}
+
+		return dateIdx[a] - dateIdx[b]
+	})
+	for idx, dateStr := range dates {
+		dateIdx[dateStr] = idx
+	}
+
+	codeSize := len(codes)
+	indicatorSize := len(indicators)
+	values := make([]*EQValue, codeSize*indicatorSize*len(dates))
+
+	for _, part := range parts {
+		partCodeSize := len(part.codes)
+		partIndicatorSize := len(part.indicators)
+
+		for idxDate, dateStr := range part.dateList {
+			for idxCode, code := range part.codes {
+				for idxIndicator, indicator := range part.indicators {
+					src := part.values[partCodeSize*partIndicatorSize*idxDate+
+						partIndicatorSize*idxCode+idxIndicator]
+					dst := codeSize*indicatorSize*dateIdx[dateStr] +
+						indicatorSize*codeIdx[code] + indicatorIdx[indicator]
+
+					if values[dst] == nil {
+						values[dst] = valuePool.Get().(*EQValue)
+					}
+					*values[dst] = *src
+				}
+			}
+		}
+	}
+
+	for idx, v := range values {
+		if v == nil {
+			v = valuePool.Get().(*EQValue)
+			*v = EQValue{}
+			values[idx] = v
+		}
+	}
+
+	data := dataPool.Get().(*EQData)
+	data.codes = codes
+	data.indicators = indicators
+	data.dateList = dates
+	data.values = values
+	data.quoteTime = slices.ContainsFunc(parts, func(v *EQData) bool {
+		return v.quoteTime
+	})
+
+	runtime.SetFinalizer(data, releaseEQData)
+
+	return data, nil
+}
@@ -0,0 +1,112 @@
+package choice4go
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+)
+
+func newTestEQData(codes, indicators, dates []string, values ...float64) *EQData {
+	data := &EQData{
+		codes:      codes,
+		indicators: indicators,
+		dateList:   dates,
+		values:     make([]*EQValue, len(values)),
+	}
+
+	for idx, v := range values {
+		value := &EQValue{valueType: ValueDouble}
+		binary.LittleEndian.PutUint64(value.valueBuffer[:], math.Float64bits(v))
+		data.values[idx] = value
+	}
+
+	return data
+}
+
+func TestMergeEQData(t *testing.T) {
+	first := newTestEQData(
+		[]string{"000001.SZ"}, []string{"OPEN", "CLOSE"},
+		[]string{"2024/1/3", "2024/1/2"},
+		3, 30, 2, 20,
+	)
+	second := newTestEQData(
+		[]string{"600000.SH"}, []string{"CLOSE"},
+		[]string{"2024/1/2"},
+		200,
+	)
+
+	merged, err := mergeEQData(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(merged.codes) != 2 || len(merged.indicators) != 2 ||
+		len(merged.dateList) != 2 {
+		t.Fatalf("unexpected merged shape: %+v", merged)
+	}
+
+	if merged.dateList[0] != "2024/1/2" {
+		t.Fatalf("dates not sorted: %v", merged.dateList)
+	}
+
+	expected := map[[2]string][]any{
+		{"2024/1/2", "000001.SZ"}: {2.0, 20.0},
+		{"2024/1/2", "600000.SH"}: {nil, 200.0},
+		{"2024/1/3", "000001.SZ"}: {3.0, 30.0},
+		{"2024/1/3", "600000.SH"}: {nil, nil},
+	}
+
+	for _, row := range merged.Iter() {
+		key := [2]string{row.Date.Format("2006/1/2"), row.Code}
+
+		for idx, name := range row.Indicators() {
+			v, _ := row.Value(name)
+
+			if v.GetValue() != expected[key][idx] {
+				t.Errorf(
+					"%v %s: expected %v, got %v",
+					key, name, expected[key][idx], v.GetValue(),
+				)
+			}
+		}
+	}
+}
+
+func TestMergeEQDataQuoteTime(t *testing.T) {
+	first := newTestEQData(
+		[]string{"000001.SZ"}, []string{"NOW", "DATE", "TIME"},
+		[]string{"2024/1/2"},
+		10.5, 20240102, 93001500,
+	)
+	first.quoteTime = true
+	second := newTestEQData(
+		[]string{"600000.SH"}, []string{"NOW", "DATE", "TIME"},
+		[]string{"2024/1/2"},
+		7.2, 20240102, 145959000,
+	)
+	second.quoteTime = true
+
+	merged, err := mergeEQData(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !merged.quoteTime {
+		t.Fatal("quote time flag dropped by merge")
+	}
+
+	expected := map[string]time.Time{
+		"000001.SZ": time.Date(2024, 1, 2, 9, 30, 1, 500*int(time.Millisecond), time.Local),
+		"600000.SH": time.Date(2024, 1, 2, 14, 59, 59, 0, time.Local),
+	}
+
+	for _, row := range merged.Iter() {
+		if !row.Date.Equal(expected[row.Code]) {
+			t.Errorf(
+				"%s: expected quote time %v, got %v",
+				row.Code, expected[row.Code], row.Date,
+			)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package choice4go
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cfn及cnq返回的资讯字段, 其中code、title及datetime为必需字段
+const (
+	newsTimeField    = "datetime"   // 展示时间
+	newsCodeField    = "code"       // 证券代码或板块代码
+	newsContentField = "content"    // 请求类型
+	newsTitleField   = "title"      // 资讯标题
+	newsIDField      = "infoCode"   // 资讯编码
+	newsSourceField  = "medianname" // 来源
+	newsURLField     = "url"        // 链接
+)
+
+// cfnquery返回的资讯板块字段
+const (
+	newsSectorCodeField   = "seccode"  // 板块代码
+	newsSectorNameField   = "secname"  // 板块名称
+	newsSectorParentField = "psecname" // 母板块中文名称
+)
+
+//go:generate stringer -type cfnMode -linecomment
+type cfnMode uint8
+
+const (
+	CfnStartToEnd cfnMode = 1 // 起止时间
+	CfnEndCount   cfnMode = 2 // 截止条数
+)
+
+// NewsItem 资讯及公告记录, 原始字段保存在Fields中
+type NewsItem struct {
+	Code     string
+	Content  string
+	Title    string
+	Time     time.Time
+	ID       string
+	Source   string
+	URL      string
+	SerialID int
+	Fields   map[string]any
+}
+
+type NewsSubscription = Subscription[NewsItem]
+
+func valueString(v *EQValue) string {
+	if v.GetType() == ValueString {
+		return v.GetString()
+	}
+
+	if result, ok := v.AsInt64(); ok {
+		return strconv.FormatInt(result, 10)
+	}
+
+	return ""
+}
+
+func rowString(row Indicator, name string) string {
+	if v, ok := row.Value(name); ok && v.Valid() {
+		return valueString(v)
+	}
+
+	return ""
+}
+
+func checkNewsFields(fun string, data *EQData) error {
+	return checkReportFields(
+		fun, data.indicators, newsCodeField, newsTitleField, newsTimeField,
+	)
+}
+
+func newNewsItem(row Indicator) NewsItem {
+	item := NewsItem{
+		Code:    rowString(row, newsCodeField),
+		Content: rowString(row, newsContentField),
+		Title:   rowString(row, newsTitleField),
+		Time:    row.Date,
+		ID:      rowString(row, newsIDField),
+		Source:  rowString(row, newsSourceField),
+		URL:     rowString(row, newsURLField),
+		Fields:  make(map[string]any, len(row.indicators)),
+	}
+
+	for idx, name := range row.indicators {
+		item.Fields[name] = row.value[idx].GetValue()
+	}
+
+	if date, err := parseEQDate(
+		strings.ReplaceAll(rowString(row, newsTimeField), "T", " "),
+	); err == nil {
+		item.Time = date
+	}
+
+	return item
+}
+
+func newNewsItems(data *EQData) ([]NewsItem, error) {
+	if err := checkNewsFields("cfn", data); err != nil {
+		return nil, err
+	}
+
+	results := make([]NewsItem, 0, len(data.codes))
+	for _, row := range data.Iter() {
+		results = append(results, newNewsItem(row))
+	}
+
+	return results, nil
+}
+
+func convertNews(msg *EQMsg) []NewsItem {
+	if msg.Data == nil {
+		return nil
+	}
+
+	if err := checkNewsFields("cnq", msg.Data); err != nil {
+		slog.Error(
+			"choice news push dropped",
+			slog.Int("serial_id", msg.SerialID),
+			slog.Any("error", err),
+		)
+
+		return nil
+	}
+
+	results := make([]NewsItem, 0, len(msg.Data.codes))
+	for _, row := range msg.Data.Iter() {
+		item := newNewsItem(row)
+		item.SerialID = msg.SerialID
+
+		results = append(results, item)
+	}
+
+	return results
+}
+
+// NewsSector 资讯板块树节点, 原始字段保存在Fields中
+type NewsSector struct {
+	Code       string
+	Name       string
+	ParentName string
+	Fields     map[string]any
+}
+
+func newNewsSectors(data *EQData) ([]NewsSector, error) {
+	if err := checkReportFields(
+		"cfnquery", data.indicators,
+		newsSectorCodeField, newsSectorNameField, newsSectorParentField,
+	); err != nil {
+		return nil, err
+	}
+
+	results := make([]NewsSector, 0, len(data.codes))
+
+	for _, row := range data.Iter() {
+		sector := NewsSector{
+			Code:       rowString(row, newsSectorCodeField),
+			Name:       rowString(row, newsSectorNameField),
+			ParentName: rowString(row, newsSectorParentField),
+			Fields:     make(map[string]any, len(row.indicators)),
+		}
+
+		for idx, name := range row.indicators {
+			sector.Fields[name] = row.value[idx].GetValue()
+		}
+
+		results = append(results, sector)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,100 @@
+package choice4go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestStringEQData(codes, indicators, dates []string, values ...string) *EQData {
+	data := &EQData{
+		codes:      codes,
+		indicators: indicators,
+		dateList:   dates,
+		values:     make([]*EQValue, len(values)),
+	}
+
+	for idx, v := range values {
+		data.values[idx] = &EQValue{valueType: ValueString, valueString: v}
+	}
+
+	return data
+}
+
+func TestNewNewsItems(t *testing.T) {
+	data := newTestStringEQData(
+		[]string{"300059.SZ"},
+		[]string{
+			"datetime", "eitime", "code", "content",
+			"title", "infoCode", "medianname", "url",
+		},
+		[]string{"2019/7/25"},
+		"2019/7/25 10:30:00", "2019/7/25 10:29:00", "300059.SZ", "report",
+		"东方财富公告", "AN201907251234", "深交所", "https://example.com/an",
+	)
+
+	items, err := newNewsItems(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("unexpected item count: %d", len(items))
+	}
+
+	item := items[0]
+	if item.Code != "300059.SZ" || item.Content != "report" ||
+		item.Title != "东方财富公告" || item.ID != "AN201907251234" ||
+		item.Source != "深交所" || item.URL != "https://example.com/an" {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+
+	if !item.Time.Equal(time.Date(2019, 7, 25, 10, 30, 0, 0, time.Local)) {
+		t.Fatalf("unexpected item time: %s", item.Time)
+	}
+}
+
+func TestNewNewsItemsMissingField(t *testing.T) {
+	data := newTestStringEQData(
+		[]string{"300059.SZ"},
+		[]string{"datetime", "code"},
+		[]string{"2019/7/25"},
+		"2019/7/25", "300059.SZ",
+	)
+
+	if _, err := newNewsItems(data); !errors.Is(err, ErrFieldMissing) {
+		t.Fatalf("expected ErrFieldMissing, got %v", err)
+	}
+
+	if items := convertNews(&EQMsg{Data: data}); items != nil {
+		t.Fatalf("push without required fields should be dropped: %+v", items)
+	}
+}
+
+func TestNewNewsSectors(t *testing.T) {
+	data := newTestStringEQData(
+		[]string{"S888010001"},
+		[]string{"seccode", "secname", "psecname"},
+		[]string{"2024/1/2"},
+		"S888010001", "宏观经济", "资讯板块",
+	)
+
+	sectors, err := newNewsSectors(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sectors) != 1 || sectors[0].Code != "S888010001" ||
+		sectors[0].Name != "宏观经济" || sectors[0].ParentName != "资讯板块" {
+		t.Fatalf("unexpected sectors: %+v", sectors)
+	}
+
+	data = newTestStringEQData(
+		[]string{"S888010001"}, []string{"seccode"}, []string{"2024/1/2"},
+		"S888010001",
+	)
+
+	if _, err := newNewsSectors(data); !errors.Is(err, ErrFieldMissing) {
+		t.Fatalf("expected ErrFieldMissing, got %v", err)
+	}
+}
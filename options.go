@@ -29,3 +29,20 @@ func (opt baseOptions) findOptIdx(prefix ...string) int {
 		return false
 	})
 }
+
+// lookupOption 按参数名(不区分大小写)查找参数值
+func lookupOption(opt Option, key string) (string, bool) {
+	if opt == nil {
+		return "", false
+	}
+
+	for _, kv := range strings.Split(opt.OptionString(), ",") {
+		name, value, found := strings.Cut(kv, "=")
+
+		if found && strings.EqualFold(strings.TrimSpace(name), key) {
+			return strings.TrimSpace(value), true
+		}
+	}
+
+	return "", false
+}
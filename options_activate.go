@@ -0,0 +1,41 @@
+package choice4go
+
+import (
+	"fmt"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+type activateOptions struct {
+	baseOptions
+
+	email string
+}
+
+func NewActivateOptions() *activateOptions {
+	return &activateOptions{}
+}
+
+func (opt *activateOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("ActivateOptions{")
+	fmt.Fprintf(buff, "Email:%s}", opt.email)
+
+	return buff.String()
+}
+
+// Email 激活成功后令牌文件userInfo将同时发送至该邮箱
+func (opt *activateOptions) Email(addr string) *activateOptions {
+	emailOpt := "email=" + addr
+
+	if optIdx := opt.findOptIdx("email"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, emailOpt)
+	} else {
+		opt.baseOptions[optIdx] = emailOpt
+	}
+
+	opt.email = addr
+	return opt
+}
@@ -0,0 +1,80 @@
+package choice4go
+
+import (
+	"fmt"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+//go:generate stringer -type cecReturnType -linecomment
+type cecReturnType uint8
+
+const (
+	CecVerify   cecReturnType = 0 // 校验代码
+	CecComplete cecReturnType = 1 // 补全代码后缀
+)
+
+type cecOptions struct {
+	baseOptions
+
+	returnType cecReturnType
+	secuType   string
+	secuMarket string
+}
+
+func NewCecOptions() *cecOptions {
+	return &cecOptions{}
+}
+
+func (opt *cecOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("CecOptions{")
+	fmt.Fprintf(buff, "ReturnType:%+v ", opt.returnType)
+	fmt.Fprintf(buff, "SecuType:%s ", opt.secuType)
+	fmt.Fprintf(buff, "SecuMarket:%s}", opt.secuMarket)
+
+	return buff.String()
+}
+
+func (opt *cecOptions) ReturnType(t cecReturnType) *cecOptions {
+	typeOpt := fmt.Sprintf("ReturnType=%d", t)
+
+	if optIdx := opt.findOptIdx("ReturnType"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, typeOpt)
+	} else {
+		opt.baseOptions[optIdx] = typeOpt
+	}
+
+	opt.returnType = t
+	return opt
+}
+
+// SecuType 补全代码时限定的证券品种, 仅CecComplete模式有效
+func (opt *cecOptions) SecuType(secuType string) *cecOptions {
+	typeOpt := fmt.Sprintf("SecuType=%s", secuType)
+
+	if optIdx := opt.findOptIdx("SecuType"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, typeOpt)
+	} else {
+		opt.baseOptions[optIdx] = typeOpt
+	}
+
+	opt.secuType = secuType
+	return opt
+}
+
+// SecuMarket 补全代码时限定的交易市场, 仅CecComplete模式有效
+func (opt *cecOptions) SecuMarket(market string) *cecOptions {
+	marketOpt := fmt.Sprintf("SecuMarket=%s", market)
+
+	if optIdx := opt.findOptIdx("SecuMarket"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, marketOpt)
+	} else {
+		opt.baseOptions[optIdx] = marketOpt
+	}
+
+	opt.secuMarket = market
+	return opt
+}
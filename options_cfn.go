@@ -0,0 +1,72 @@
+package choice4go
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+type cfnOptions struct {
+	baseOptions
+
+	start, end time.Time
+	count      int
+}
+
+func NewCfnOptions() *cfnOptions {
+	return &cfnOptions{}
+}
+
+func (opt *cfnOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("CfnOptions{")
+	fmt.Fprintf(buff, "Start:%s ", opt.start.Format(time.DateTime))
+	fmt.Fprintf(buff, "End:%s ", opt.end.Format(time.DateTime))
+	fmt.Fprintf(buff, "Count:%d}", opt.count)
+
+	return buff.String()
+}
+
+// StartTime 资讯起始时间, 仅CfnStartToEnd模式有效
+func (opt *cfnOptions) StartTime(t time.Time) *cfnOptions {
+	startOpt := fmt.Sprintf("starttime=%s", t.Format("20060102150405"))
+
+	if optIdx := opt.findOptIdx("starttime"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, startOpt)
+	} else {
+		opt.baseOptions[optIdx] = startOpt
+	}
+
+	opt.start = t
+	return opt
+}
+
+func (opt *cfnOptions) EndTime(t time.Time) *cfnOptions {
+	endOpt := fmt.Sprintf("endtime=%s", t.Format("20060102150405"))
+
+	if optIdx := opt.findOptIdx("endtime"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, endOpt)
+	} else {
+		opt.baseOptions[optIdx] = endOpt
+	}
+
+	opt.end = t
+	return opt
+}
+
+// Count 截止时间前的资讯条数, 仅CfnEndCount模式有效
+func (opt *cfnOptions) Count(n int) *cfnOptions {
+	countOpt := fmt.Sprintf("count=%d", n)
+
+	if optIdx := opt.findOptIdx("count"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, countOpt)
+	} else {
+		opt.baseOptions[optIdx] = countOpt
+	}
+
+	opt.count = n
+	return opt
+}
@@ -0,0 +1,89 @@
+package choice4go
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+type cpsOptions struct {
+	baseOptions
+
+	orderBy    CpsField
+	orderDESC  bool
+	top        string
+	sectorDate time.Time
+}
+
+func NewCpsOptions() *cpsOptions {
+	return &cpsOptions{}
+}
+
+func (opt *cpsOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("CpsOptions{")
+	fmt.Fprintf(buff, "OrderBy:%s ", opt.orderBy)
+	fmt.Fprintf(buff, "OrderDESC:%+v ", opt.orderDESC)
+	fmt.Fprintf(buff, "Top:%s ", opt.top)
+	fmt.Fprintf(buff, "SectorDate:%s}", opt.sectorDate.Format("2006-01-02"))
+
+	return buff.String()
+}
+
+func (opt *cpsOptions) OrderBy(field CpsField, desc bool) *cpsOptions {
+	var orderOpt string
+	if desc {
+		orderOpt = fmt.Sprintf("orderby=rd(%s)", field)
+	} else {
+		orderOpt = fmt.Sprintf("orderby=ra(%s)", field)
+	}
+
+	if optIdx := opt.findOptIdx("orderby"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, orderOpt)
+	} else {
+		opt.baseOptions[optIdx] = orderOpt
+	}
+
+	opt.orderBy, opt.orderDESC = field, desc
+	return opt
+}
+
+// TopMax 取field最大的前n条选股结果
+func (opt *cpsOptions) TopMax(field CpsField, n int) *cpsOptions {
+	return opt.setTop(fmt.Sprintf("max(%s,%d)", field, n))
+}
+
+// TopMin 取field最小的前n条选股结果
+func (opt *cpsOptions) TopMin(field CpsField, n int) *cpsOptions {
+	return opt.setTop(fmt.Sprintf("min(%s,%d)", field, n))
+}
+
+func (opt *cpsOptions) setTop(top string) *cpsOptions {
+	topOpt := "top=" + top
+
+	if optIdx := opt.findOptIdx("top"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, topOpt)
+	} else {
+		opt.baseOptions[optIdx] = topOpt
+	}
+
+	opt.top = top
+	return opt
+}
+
+// SectorDate 板块成分日期, codes为板块代码时有效
+func (opt *cpsOptions) SectorDate(t time.Time) *cpsOptions {
+	dateOpt := fmt.Sprintf("sectordate=%s", t.Format("2006-01-02"))
+
+	if optIdx := opt.findOptIdx("sectordate"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, dateOpt)
+	} else {
+		opt.baseOptions[optIdx] = dateOpt
+	}
+
+	opt.sectorDate = t
+	return opt
+}
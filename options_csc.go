@@ -0,0 +1,84 @@
+package choice4go
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+//go:generate stringer -type barSize -linecomment
+type barSize uint8
+
+const (
+	Bar1Min  barSize = 1  // 1分钟
+	Bar3Min  barSize = 3  // 3分钟
+	Bar5Min  barSize = 5  // 5分钟
+	Bar15Min barSize = 15 // 15分钟
+	Bar30Min barSize = 30 // 30分钟
+	Bar60Min barSize = 60 // 60分钟
+)
+
+type cscOptions struct {
+	baseOptions
+
+	barSize    barSize
+	adjustFlag adjustFlag
+}
+
+func NewCscOptions() *cscOptions {
+	return &cscOptions{
+		barSize:    Bar1Min,
+		adjustFlag: NoAdjusted,
+	}
+}
+
+func (opt *cscOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("CscOptions{")
+	fmt.Fprintf(buff, "BarSize:%d ", opt.barSize)
+	fmt.Fprintf(buff, "Adjust:%+v}", opt.adjustFlag)
+
+	return buff.String()
+}
+
+func (opt *cscOptions) BarSize(size barSize) *cscOptions {
+	sizeOpt := fmt.Sprintf("Period=%d", size)
+
+	if optIdx := opt.findOptIdx("Period"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, sizeOpt)
+	} else {
+		opt.baseOptions[optIdx] = sizeOpt
+	}
+
+	opt.barSize = size
+	return opt
+}
+
+func (opt *cscOptions) Adjust(flag adjustFlag) *cscOptions {
+	flagOpt := fmt.Sprintf("AdjustFlag=%d", flag)
+
+	if optIdx := opt.findOptIdx("AdjustFlag"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, flagOpt)
+	} else {
+		opt.baseOptions[optIdx] = flagOpt
+	}
+
+	opt.adjustFlag = flag
+	return opt
+}
+
+// minuteArgs 校验csc/cmc/chmc的单个证券代码, 并格式化起止时间
+func minuteArgs(name, code string, start, end time.Time) (string, string, error) {
+	if code == "" || strings.Contains(code, ",") {
+		return "", "", fmt.Errorf(
+			"%w: %s only support single code", ErrInvalidArgs, name,
+		)
+	}
+
+	return start.Format("2006-01-02 15:04:05"),
+		end.Format("2006-01-02 15:04:05"), nil
+}
@@ -0,0 +1,51 @@
+package choice4go
+
+import (
+	"fmt"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+//go:generate stringer -type pushType -linecomment
+type pushType uint8
+
+const (
+	PushIncremental     pushType = 0 // 增量推送
+	PushFull            pushType = 1 // 全量推送
+	PushIncrementalFull pushType = 2 // 证券增量指标全量推送
+)
+
+type csqOptions struct {
+	baseOptions
+
+	pushType pushType
+}
+
+func NewCsqOptions() *csqOptions {
+	return &csqOptions{
+		pushType: PushIncremental,
+	}
+}
+
+func (opt *csqOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("CsqOptions{")
+	fmt.Fprintf(buff, "PushType:%+v}", opt.pushType)
+
+	return buff.String()
+}
+
+func (opt *csqOptions) PushType(t pushType) *csqOptions {
+	pushOpt := fmt.Sprintf("Pushtype=%d", t)
+
+	if optIdx := opt.findOptIdx("Pushtype"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, pushOpt)
+	} else {
+		opt.baseOptions[optIdx] = pushOpt
+	}
+
+	opt.pushType = t
+	return opt
+}
@@ -0,0 +1,99 @@
+package choice4go
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// edbFrequency 宏观指标日期频率, 取值同edbquery返回的FREQUENCY字段
+//
+//go:generate stringer -type edbFrequency -linecomment
+type edbFrequency uint8
+
+const (
+	EdbDaily       edbFrequency = 1 // 日
+	EdbWeekly      edbFrequency = 2 // 周
+	EdbTenDays     edbFrequency = 3 // 旬
+	EdbHalfMonthly edbFrequency = 4 // 半月
+	EdbMonthly     edbFrequency = 5 // 月
+	EdbQuarterly   edbFrequency = 6 // 季
+	EdbHalfYearly  edbFrequency = 7 // 半年
+	EdbYearly      edbFrequency = 8 // 年
+	EdbIrregular   edbFrequency = 9 // 不定期
+)
+
+// edbOptions 宏观数据函数可选参数
+//
+// edb文档中的可选参数仅有StartDate、EndDate、IsLatest、IsPublishDate、RECVtimeout及FixDate,
+// 不支持按更新时间过滤, 因此不提供更新时间选项; 指标的更新时间可通过EdbQuery的UPDATETIME字段查询.
+type edbOptions struct {
+	baseOptions
+
+	start, end time.Time
+	latest     bool
+	frequency  edbFrequency
+}
+
+func NewEdbOptions() *edbOptions {
+	return &edbOptions{}
+}
+
+func (opt *edbOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("EdbOptions{")
+	fmt.Fprintf(buff, "Start:%s ", opt.start.Format("2006-01-02"))
+	fmt.Fprintf(buff, "End:%s ", opt.end.Format("2006-01-02"))
+	fmt.Fprintf(buff, "Latest:%+v ", opt.latest)
+	fmt.Fprintf(buff, "Frequency:%+v}", opt.frequency)
+
+	return buff.String()
+}
+
+func (opt *edbOptions) DateRange(start, end time.Time) *edbOptions {
+	startOpt := fmt.Sprintf("StartDate=%s", start.Format("2006-01-02"))
+	endOpt := fmt.Sprintf("EndDate=%s", end.Format("2006-01-02"))
+
+	if optIdx := opt.findOptIdx("StartDate"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, startOpt)
+	} else {
+		opt.baseOptions[optIdx] = startOpt
+	}
+
+	if optIdx := opt.findOptIdx("EndDate"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, endOpt)
+	} else {
+		opt.baseOptions[optIdx] = endOpt
+	}
+
+	opt.start, opt.end = start, end
+	return opt
+}
+
+// Latest 仅返回最新一期数据
+func (opt *edbOptions) Latest() *edbOptions {
+	if optIdx := opt.findOptIdx("IsLatest"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, "IsLatest=1")
+	} else {
+		opt.baseOptions[optIdx] = "IsLatest=1"
+	}
+
+	opt.latest = true
+	return opt
+}
+
+func (opt *edbOptions) Frequency(f edbFrequency) *edbOptions {
+	freqOpt := fmt.Sprintf("Period=%d", f)
+
+	if optIdx := opt.findOptIdx("Period"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, freqOpt)
+	} else {
+		opt.baseOptions[optIdx] = freqOpt
+	}
+
+	opt.frequency = f
+	return opt
+}
@@ -0,0 +1,41 @@
+package choice4go
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+type snapshotOptions struct {
+	baseOptions
+
+	recvTimeout time.Duration
+}
+
+func NewSnapshotOptions() *snapshotOptions {
+	return &snapshotOptions{}
+}
+
+func (opt *snapshotOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("SnapshotOptions{")
+	fmt.Fprintf(buff, "RecvTimeout:%+v}", opt.recvTimeout)
+
+	return buff.String()
+}
+
+func (opt *snapshotOptions) RecvTimeout(d time.Duration) *snapshotOptions {
+	timeOpt := fmt.Sprintf("RECVtimeout=%.0f", d.Seconds())
+
+	if optIdx := opt.findOptIdx("RECVtimeout"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, timeOpt)
+	} else {
+		opt.baseOptions[optIdx] = timeOpt
+	}
+
+	opt.recvTimeout = d
+	return opt
+}
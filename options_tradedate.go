@@ -0,0 +1,91 @@
+package choice4go
+
+import (
+	"fmt"
+
+	"github.com/valyala/bytebufferpool"
+)
+
+// 常用交易日历市场代码
+const (
+	MarketSSE  = "CNSESH" // 上交所
+	MarketSZSE = "CNSESZ" // 深交所
+)
+
+type tradeDatesOptions struct {
+	baseOptions
+
+	market   string
+	period   period
+	dateDESC bool
+}
+
+func NewTradeDatesOptions() *tradeDatesOptions {
+	return &tradeDatesOptions{
+		period: Daily,
+	}
+}
+
+func (opt *tradeDatesOptions) String() string {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.WriteString("TradeDatesOptions{")
+	fmt.Fprintf(buff, "Market:%s ", opt.market)
+	fmt.Fprintf(buff, "Period:%+v ", opt.period)
+	if opt.dateDESC {
+		fmt.Fprintf(buff, "DateSort:DESC}")
+	} else {
+		fmt.Fprintf(buff, "DateSort:ASC}")
+	}
+
+	return buff.String()
+}
+
+func (opt *tradeDatesOptions) Market(market string) *tradeDatesOptions {
+	marketOpt := fmt.Sprintf("Market=%s", market)
+
+	if optIdx := opt.findOptIdx("Market"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, marketOpt)
+	} else {
+		opt.baseOptions[optIdx] = marketOpt
+	}
+
+	opt.market = market
+	return opt
+}
+
+func (opt *tradeDatesOptions) Period(p period) *tradeDatesOptions {
+	periodOpt := fmt.Sprintf("Period=%d", p)
+
+	if optIdx := opt.findOptIdx("Period"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, periodOpt)
+	} else {
+		opt.baseOptions[optIdx] = periodOpt
+	}
+
+	opt.period = p
+	return opt
+}
+
+func (opt *tradeDatesOptions) DateASC() *tradeDatesOptions {
+	if optIdx := opt.findOptIdx("Order"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, "Order=1")
+	} else {
+		opt.baseOptions[optIdx] = "Order=1"
+	}
+
+	opt.dateDESC = false
+	return opt
+}
+
+func (opt *tradeDatesOptions) DateDESC() *tradeDatesOptions {
+	if optIdx := opt.findOptIdx("Order"); optIdx < 0 {
+		opt.baseOptions = append(opt.baseOptions, "Order=2")
+	} else {
+		opt.baseOptions[optIdx] = "Order=2"
+	}
+
+	opt.dateDESC = true
+	return opt
+}
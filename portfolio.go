@@ -0,0 +1,174 @@
+package choice4go
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	MAX_ORDER_CODE_LEN      = 19
+	MAX_PORTFOLIO_INIT_FUND = 99999999999
+)
+
+//go:generate stringer -type OperateType -linecomment
+type OperateType uint8
+
+const (
+	OpDefault    OperateType = iota // 默认
+	OpBuy                           // 买入
+	OpSell                          // 卖出
+	OpPurchase                      // 申购
+	OpRedemption                    // 赎回
+)
+
+type transferDirection string
+
+const (
+	TransferIn  transferDirection = "IN"
+	TransferOut transferDirection = "OUT"
+)
+
+// Order 组合下单信息, 对应SDK中的ORDERINFO
+//
+// Volume含义由下单选项OrderMode决定, 0:交易数量 1:持仓目标数量 2:持仓目标权重.
+// OpDefault时按Volume正负区分买入卖出.
+type Order struct {
+	Code    string
+	Volume  float64
+	Price   float32
+	Time    time.Time
+	Operate OperateType
+	Cost    float32
+	Rate    float32
+}
+
+// checkOrders 校验下单列表, 代码不可为空且长度不超过MAX_ORDER_CODE_LEN
+func checkOrders(orders []Order) error {
+	if len(orders) <= 0 {
+		return fmt.Errorf(
+			"%w: orders is empty", ErrInvalidArgs,
+		)
+	}
+
+	for _, order := range orders {
+		if order.Code == "" || len(order.Code) > MAX_ORDER_CODE_LEN {
+			return fmt.Errorf(
+				"%w: invalid order code %q", ErrInvalidArgs, order.Code,
+			)
+		}
+	}
+
+	return nil
+}
+
+// orderDateTime 下单时间转换为ORDERINFO中yyyymmdd格式的日期及hhmmss格式的时间, 零值时均为0
+func orderDateTime(t time.Time) (date, clock int) {
+	if t.IsZero() {
+		return 0, 0
+	}
+
+	date = t.Year()*10000 + int(t.Month())*100 + t.Day()
+	clock = t.Hour()*10000 + t.Minute()*100 + t.Second()
+
+	return
+}
+
+// Portfolio 模拟组合
+type Portfolio struct {
+	ins  *Choice
+	Code string
+}
+
+func (ins *Choice) Portfolio(code string) *Portfolio {
+	return &Portfolio{ins: ins, Code: code}
+}
+
+func (p *Portfolio) checkCode() error {
+	if p.Code == "" {
+		return fmt.Errorf(
+			"%w: portfolio code is empty", ErrInvalidArgs,
+		)
+	}
+
+	return nil
+}
+
+// Create 新建组合, initialFund最大为99999999999
+func (p *Portfolio) Create(
+	name string, initialFund int64, remark string, options Option,
+) error {
+	if err := p.checkCode(); err != nil {
+		return err
+	}
+
+	if initialFund <= 0 || initialFund > MAX_PORTFOLIO_INIT_FUND {
+		return fmt.Errorf(
+			"%w: initial fund out of range: %d", ErrInvalidArgs, initialFund,
+		)
+	}
+
+	return p.ins.pcreate(p.Code, name, initialFund, remark, options)
+}
+
+func (p *Portfolio) Delete(options Option) error {
+	if err := p.checkCode(); err != nil {
+		return err
+	}
+
+	return p.ins.pdelete(p.Code, options)
+}
+
+// Order 批量下单
+func (p *Portfolio) Order(
+	orders []Order, remark string, options Option,
+) error {
+	if err := p.checkCode(); err != nil {
+		return err
+	}
+
+	if err := checkOrders(orders); err != nil {
+		return err
+	}
+
+	return p.ins.porder(p.Code, orders, remark, options)
+}
+
+// Query 组合账户关系查询
+func (p *Portfolio) Query(options Option) (*EQData, error) {
+	return p.ins.pquery(options)
+}
+
+// Report 组合报表查询, indicator为报表名称
+func (p *Portfolio) Report(indicator string, options Option) (*EQData, error) {
+	if err := p.checkCode(); err != nil {
+		return nil, err
+	}
+
+	return p.ins.preport(p.Code, indicator, options)
+}
+
+// TransferCash 组合资金调配
+func (p *Portfolio) TransferCash(
+	direction transferDirection, date time.Time,
+	cash float64, remark string, options Option,
+) error {
+	if err := p.checkCode(); err != nil {
+		return err
+	}
+
+	switch direction {
+	case TransferIn, TransferOut:
+	default:
+		return fmt.Errorf(
+			"%w: unknown transfer direction %q", ErrInvalidArgs, direction,
+		)
+	}
+
+	if cash <= 0 {
+		return fmt.Errorf(
+			"%w: transfer cash must be positive", ErrInvalidArgs,
+		)
+	}
+
+	return p.ins.pctransfer(p.Code, direction, date, cash, remark, options)
+}
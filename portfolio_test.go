@@ -0,0 +1,64 @@
+package choice4go
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckOrders(t *testing.T) {
+	cases := []struct {
+		name   string
+		orders []Order
+		valid  bool
+	}{
+		{"empty", nil, false},
+		{"empty code", []Order{{Code: ""}}, false},
+		{"max code", []Order{{Code: strings.Repeat("1", MAX_ORDER_CODE_LEN)}}, true},
+		{"code overflow", []Order{
+			{Code: "300059.SZ"},
+			{Code: strings.Repeat("1", MAX_ORDER_CODE_LEN+1)},
+		}, false},
+	}
+
+	for _, c := range cases {
+		err := checkOrders(c.orders)
+
+		if c.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		}
+
+		if !c.valid && !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("%s: expect ErrInvalidArgs, got %v", c.name, err)
+		}
+	}
+
+	p := (&Choice{}).Portfolio("P001")
+	if err := p.Order(nil, "", nil); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("empty order list should be rejected before calling sdk, got %v", err)
+	}
+}
+
+func TestOrderDateTime(t *testing.T) {
+	cases := []struct {
+		tm          time.Time
+		date, clock int
+	}{
+		{time.Time{}, 0, 0},
+		{time.Date(2024, 1, 2, 9, 5, 7, 0, time.Local), 20240102, 90507},
+		{time.Date(2023, 12, 31, 15, 0, 0, 0, time.Local), 20231231, 150000},
+		{time.Date(2024, 3, 8, 0, 0, 0, 0, time.Local), 20240308, 0},
+	}
+
+	for _, c := range cases {
+		date, clock := orderDateTime(c.tm)
+
+		if date != c.date || clock != c.clock {
+			t.Errorf(
+				"%v: expect %d %d, got %d %d",
+				c.tm, c.date, c.clock, date, clock,
+			)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package choice4go
+
+import (
+	"sync"
+	"time"
+)
+
+// QuotaUsage 请求用量统计, Cells为返回的数据点数(证券 × 指标 × 日期)
+type QuotaUsage struct {
+	Calls int64
+	Cells int64
+}
+
+// quotaMeter 按自然日统计各函数的请求次数及返回数据点数
+type quotaMeter struct {
+	lock  sync.Mutex
+	day   time.Time
+	usage map[string]*QuotaUsage
+}
+
+func (m *quotaMeter) rollover(now time.Time) {
+	if day := truncateDay(now); !day.Equal(m.day) {
+		m.day = day
+		m.usage = make(map[string]*QuotaUsage)
+	}
+}
+
+func (m *quotaMeter) record(name string, cells int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.rollover(time.Now())
+
+	usage, exist := m.usage[name]
+	if !exist {
+		usage = &QuotaUsage{}
+		m.usage[name] = usage
+	}
+
+	usage.Calls++
+	usage.Cells += int64(cells)
+}
+
+func (m *quotaMeter) snapshot() map[string]QuotaUsage {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.rollover(time.Now())
+
+	results := make(map[string]QuotaUsage, len(m.usage))
+	for name, usage := range m.usage {
+		results[name] = *usage
+	}
+
+	return results
+}
+
+func (m *quotaMeter) reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.day = time.Time{}
+	m.rollover(time.Now())
+}
+
+func (data *EQData) cells() int {
+	if data == nil {
+		return 0
+	}
+
+	return len(data.codes) * len(data.indicators) * len(data.dateList)
+}
+
+func (ctr *EQCtrData) cells() int {
+	if ctr == nil {
+		return 0
+	}
+
+	return ctr.row * ctr.column
+}
+
+// Usage 返回当日各函数的请求用量
+func (ins *Choice) Usage() map[string]QuotaUsage {
+	return ins.quota.snapshot()
+}
+
+// TotalUsage 返回当日全部函数的请求用量合计
+func (ins *Choice) TotalUsage() QuotaUsage {
+	var total QuotaUsage
+
+	for _, usage := range ins.quota.snapshot() {
+		total.Calls += usage.Calls
+		total.Cells += usage.Cells
+	}
+
+	return total
+}
+
+// ResetUsage 清空用量统计
+func (ins *Choice) ResetUsage() {
+	ins.quota.reset()
+}
@@ -0,0 +1,140 @@
+package choice4go
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy 同步查询失败重试策略, 仅作用于Csd/Css/CSec, MaxAttempts不大于1时不重试
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Jitter 重试等待时长的随机抖动比例, 取值[0, 1]
+	Jitter float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Jitter:      0.2,
+}
+
+// expBackoff 第attempt次失败后的指数退避时长, 上限为maxWait
+func expBackoff(base, maxWait time.Duration, attempt int) time.Duration {
+	wait := base
+
+	for range attempt - 1 {
+		if wait >= maxWait/2 {
+			return maxWait
+		}
+
+		wait *= 2
+	}
+
+	return min(wait, maxWait)
+}
+
+// backoff 第attempt次失败后的等待时长, 含随机抖动
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := expBackoff(p.BaseDelay, p.MaxDelay, attempt)
+
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		wait = time.Duration(
+			float64(wait) * (1 + jitter*(rand.Float64()*2-1)),
+		)
+	}
+
+	return wait
+}
+
+// retryable 仅超时、网络及请求频次过高等瞬时错误可重试, 参数及账户权限错误不重试
+func retryable(err error) bool {
+	switch {
+	case errors.Is(err, ErrParam), errors.Is(err, ErrAccount):
+		return false
+	case errors.Is(err, ErrTimeout),
+		errors.Is(err, ErrRateLimited),
+		errors.Is(err, ErrNetwork):
+		return true
+	default:
+		return false
+	}
+}
+
+// retryFuns 可重试的查询函数, 仅限csd/css/cses, 其余查询及组合下单等写操作均只调用一次
+var retryFuns = map[string]bool{
+	"csd":  true,
+	"css":  true,
+	"cses": true,
+}
+
+// normalize 未设置(不大于0)的BaseDelay及MaxDelay取DefaultRetryPolicy中的值
+func (p RetryPolicy) normalize() RetryPolicy {
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = max(DefaultRetryPolicy.MaxDelay, p.BaseDelay)
+	}
+
+	p.MaxDelay = max(p.MaxDelay, p.BaseDelay)
+
+	return p
+}
+
+// SetRetryPolicy 设置同步查询重试策略, 传入nil时恢复DefaultRetryPolicy
+//
+// 未设置的BaseDelay及MaxDelay沿用DefaultRetryPolicy中的值.
+func (ins *Choice) SetRetryPolicy(policy *RetryPolicy) {
+	if policy == nil {
+		ins.retry.Store(nil)
+		return
+	}
+
+	p := policy.normalize()
+	ins.retry.Store(&p)
+}
+
+// RetryPolicy 返回当前生效的重试策略
+func (ins *Choice) RetryPolicy() RetryPolicy {
+	if p := ins.retry.Load(); p != nil {
+		return *p
+	}
+
+	return DefaultRetryPolicy
+}
+
+// queryRetryPolicy 返回name查询生效的重试策略, 不可重试的查询MaxAttempts为1
+func (ins *Choice) queryRetryPolicy(name string) RetryPolicy {
+	policy := ins.RetryPolicy()
+
+	if !retryFuns[name] {
+		policy.MaxAttempts = 1
+	}
+
+	return policy
+}
+
+// waitRetry 等待重试间隔, choice停止或ctx结束时提前返回false
+func (ins *Choice) waitRetry(ctx context.Context, wait time.Duration) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ins.rootDone():
+		return false
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,114 @@
+package choice4go
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryable(t *testing.T) {
+	for code, expect := range map[EQErr]bool{
+		EQERR_SERVICE_TIMEOUT:      true,
+		EQERR_FREQUENCY_OVER:       true,
+		EQERR_SOCKET_ERR:           true,
+		EQERR_WAIT_NET_RES_TIMEOUT: true,
+		EQERR_NO_DATA:              false,
+		EQERR_PARAM_ERR:            false,
+		EQERR_NO_ACCESS:            false,
+		EQERR_LOGIN_DISCONNECT:     false,
+	} {
+		err := fmt.Errorf("wrapped: %w", &EQError{Code: code})
+
+		if retryable(err) != expect {
+			t.Errorf("code[%d] retryable expect %+v", code, expect)
+		}
+	}
+
+	if retryable(ErrInvalidArgs) {
+		t.Error("non sdk error should not be retried")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+		Jitter:    0.5,
+	}
+
+	for attempt, base := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		8: time.Second,
+	} {
+		for range 100 {
+			wait := policy.backoff(attempt)
+
+			if wait < base/2 || wait > base*3/2 {
+				t.Fatalf(
+					"attempt[%d] backoff %s out of jitter range of %s",
+					attempt, wait, base,
+				)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyNormalize(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5}.normalize()
+
+	if p.BaseDelay != DefaultRetryPolicy.BaseDelay ||
+		p.MaxDelay != DefaultRetryPolicy.MaxDelay {
+		t.Fatalf("partial policy not filled from default: %+v", p)
+	}
+
+	if wait := p.backoff(1); wait <= 0 {
+		t.Fatalf("partial policy backoff should be positive, got %s", wait)
+	}
+
+	p = RetryPolicy{BaseDelay: time.Minute}.normalize()
+	if p.MaxDelay != time.Minute {
+		t.Fatalf("max delay should not be less than base delay: %+v", p)
+	}
+}
+
+func TestQueryRetryPolicy(t *testing.T) {
+	ins := &Choice{}
+	ins.SetRetryPolicy(&RetryPolicy{MaxAttempts: 5})
+
+	for _, name := range []string{"csd", "css", "cses"} {
+		if p := ins.queryRetryPolicy(name); p.MaxAttempts != 5 {
+			t.Fatalf("%s should retry with policy, got %+v", name, p)
+		}
+	}
+
+	for _, name := range []string{
+		"tradedates", "sector", "edb", "cfn", "cps",
+		"pquery", "preport", "porder", "pcreate", "pdelete", "pctransfer",
+	} {
+		if p := ins.queryRetryPolicy(name); p.MaxAttempts != 1 {
+			t.Fatalf("%s should not retry, got %+v", name, p)
+		}
+	}
+}
+
+func TestWaitRetryContext(t *testing.T) {
+	ins := &Choice{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	begin := time.Now()
+	if ins.waitRetry(ctx, time.Minute) {
+		t.Fatal("wait should be aborted by canceled ctx")
+	}
+
+	if time.Since(begin) > time.Second {
+		t.Fatal("wait not aborted in time")
+	}
+
+	if !ins.waitRetry(context.Background(), time.Millisecond) {
+		t.Fatal("wait should finish without cancel")
+	}
+}
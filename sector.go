@@ -0,0 +1,38 @@
+package choice4go
+
+// sector返回的成分证券字段
+const (
+	sectorCodeField = "SECUCODE"          // 证券代码
+	sectorNameField = "SECURITYSHORTNAME" // 证券简称
+)
+
+// SectorConstituent 板块成分证券
+type SectorConstituent struct {
+	Code string
+	Name string
+}
+
+func newSectorConstituents(data *EQData) ([]SectorConstituent, error) {
+	if err := checkReportFields(
+		"sector", data.indicators, sectorCodeField, sectorNameField,
+	); err != nil {
+		return nil, err
+	}
+
+	results := make([]SectorConstituent, 0, len(data.codes))
+
+	for _, row := range data.Iter() {
+		constituent := SectorConstituent{
+			Code: rowString(row, sectorCodeField),
+			Name: rowString(row, sectorNameField),
+		}
+
+		if constituent.Code == "" {
+			constituent.Code = row.Code
+		}
+
+		results = append(results, constituent)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,35 @@
+package choice4go
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewSectorConstituents(t *testing.T) {
+	data := newTestStringEQData(
+		[]string{"000001.SZ", "600000.SH"},
+		[]string{"SECUCODE", "SECURITYSHORTNAME"},
+		[]string{"2016/4/26"},
+		"000001.SZ", "平安银行", "600000.SH", "浦发银行",
+	)
+
+	results, err := newSectorConstituents(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 ||
+		results[0] != (SectorConstituent{Code: "000001.SZ", Name: "平安银行"}) ||
+		results[1] != (SectorConstituent{Code: "600000.SH", Name: "浦发银行"}) {
+		t.Fatalf("unexpected constituents: %+v", results)
+	}
+
+	data = newTestStringEQData(
+		[]string{"000001.SZ"}, []string{"SECUCODE"}, []string{"2016/4/26"},
+		"000001.SZ",
+	)
+
+	if _, err := newSectorConstituents(data); !errors.Is(err, ErrFieldMissing) {
+		t.Fatalf("expected ErrFieldMissing, got %v", err)
+	}
+}
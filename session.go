@@ -0,0 +1,283 @@
+package choice4go
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	sessionStateBufferSize   = 16
+	defaultSessionMinBackoff = time.Second
+	defaultSessionMaxBackoff = time.Minute
+)
+
+//go:generate stringer -type SessionState -linecomment
+type SessionState uint8
+
+const (
+	SessionConnected    SessionState = iota // 已连接
+	SessionDisconnected                     // 连接断开
+	SessionReconnecting                     // 重新登录中
+	SessionFailed                           // 重连失败
+	SessionClosed                           // 会话监管已关闭
+)
+
+// SessionStateChange 会话连接状态变化, Code为触发断线的错误码
+type SessionStateChange struct {
+	State   SessionState
+	Attempt int
+	Code    int
+	Err     error
+	Time    time.Time
+}
+
+// SessionPolicy 断线重连策略, MaxAttempts为0时无限重试
+type SessionPolicy struct {
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	MaxAttempts int
+}
+
+// backoff 第attempt次重连失败后的等待时长, 按指数增长至MaxBackoff
+func (p *SessionPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinBackoff
+	if wait <= 0 {
+		wait = defaultSessionMinBackoff
+	}
+
+	maxWait := p.MaxBackoff
+	if maxWait <= 0 {
+		maxWait = defaultSessionMaxBackoff
+	}
+
+	return expBackoff(wait, maxWait, attempt)
+}
+
+// sessionLost 判断错误码是否表示登录会话已失效, 需要重新登录
+func sessionLost(code int) bool {
+	switch EQErr(code) {
+	case EQERR_LOGIN_DISCONNECT,
+		EQERR_NO_LOGIN,
+		EQERR_QUOTE_RECONNECT_FAIL,
+		EQERR_INFO_RECONNECT_FAIL,
+		EQERR_CHQQUOTE_RECONNECT_FAIL:
+		return true
+	default:
+		return false
+	}
+}
+
+type restorer interface {
+	restore() error
+}
+
+// Session 受监管的登录会话, 账号掉线或行情重连失败时自动重新登录并恢复订阅
+type Session struct {
+	ins     *Choice
+	user    string
+	pass    string
+	options Option
+	policy  SessionPolicy
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	lost        chan int
+	stopMonitor func()
+
+	state  atomic.Uint32
+	states chan SessionStateChange
+}
+
+// StartSession 登录并启动会话监管, ctx结束或调用Close时停止监管, 但不会调用Stop
+func (ins *Choice) StartSession(
+	ctx context.Context,
+	user, pass string,
+	options Option,
+	policy *SessionPolicy,
+) (*Session, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := ins.Start(ctx, user, pass, options); err != nil {
+		return nil, err
+	}
+
+	sess := &Session{
+		ins:     ins,
+		user:    user,
+		pass:    pass,
+		options: options,
+		done:    make(chan struct{}),
+		lost:    make(chan int, 1),
+		states:  make(chan SessionStateChange, sessionStateBufferSize),
+	}
+
+	if policy != nil {
+		sess.policy = *policy
+	}
+
+	sess.ctx, sess.cancel = context.WithCancel(ctx)
+	sess.stopMonitor = msgDispatcher.monitor(MsgHandler(sess.onMessage))
+
+	go sess.run()
+
+	return sess, nil
+}
+
+func (sess *Session) onMessage(msg *EQMsg) {
+	if !sessionLost(msg.ErrCode) {
+		return
+	}
+
+	select {
+	case sess.lost <- msg.ErrCode:
+	default:
+		// 已有待处理的断线通知
+	}
+}
+
+func (sess *Session) emit(change SessionStateChange) {
+	change.Time = time.Now()
+	sess.state.Store(uint32(change.State))
+
+	slog.Info(
+		"choice session state changed",
+		slog.String("state", change.State.String()),
+		slog.Int("attempt", change.Attempt),
+		slog.Int("code", change.Code),
+		slog.Any("error", change.Err),
+	)
+
+	select {
+	case sess.states <- change:
+	default:
+		slog.Warn(
+			"choice session state buffer full, change dropped",
+			slog.String("state", change.State.String()),
+		)
+	}
+}
+
+func (sess *Session) run() {
+	defer func() {
+		sess.stopMonitor()
+		sess.emit(SessionStateChange{State: SessionClosed})
+		close(sess.states)
+		close(sess.done)
+	}()
+
+	rootDone := sess.ins.rootDone()
+
+	sess.emit(SessionStateChange{State: SessionConnected})
+
+	for {
+		select {
+		case <-sess.ctx.Done():
+			return
+		case <-rootDone:
+			return
+		case code := <-sess.lost:
+			sess.emit(SessionStateChange{
+				State: SessionDisconnected,
+				Code:  code,
+				Err:   sess.ins.errorOf(code),
+			})
+
+			if err := sess.reconnect(code); err != nil {
+				sess.emit(SessionStateChange{
+					State: SessionFailed,
+					Code:  code,
+					Err:   err,
+				})
+				return
+			}
+		}
+	}
+}
+
+func (sess *Session) reconnect(code int) error {
+	for attempt := 1; ; attempt++ {
+		sess.emit(SessionStateChange{
+			State:   SessionReconnecting,
+			Attempt: attempt,
+			Code:    code,
+		})
+
+		err := sess.ins.relogin(sess.user, sess.pass, sess.options)
+		if err == nil {
+			// 丢弃重新登录期间的断线通知
+			select {
+			case <-sess.lost:
+			default:
+			}
+
+			sess.restoreSubscriptions()
+			sess.emit(SessionStateChange{
+				State:   SessionConnected,
+				Attempt: attempt,
+			})
+
+			return nil
+		}
+
+		slog.Warn(
+			"choice session relogin failed",
+			slog.Int("attempt", attempt),
+			slog.Any("error", err),
+		)
+
+		if errors.Is(err, ErrNeedActivate) ||
+			errors.Is(err, ErrInitialized) ||
+			(sess.policy.MaxAttempts > 0 && attempt >= sess.policy.MaxAttempts) {
+			return err
+		}
+
+		timer := time.NewTimer(sess.policy.backoff(attempt))
+		select {
+		case <-sess.ctx.Done():
+			timer.Stop()
+			return sess.ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (sess *Session) restoreSubscriptions() {
+	sess.ins.subscriptions.Range(func(key, value any) bool {
+		if err := value.(restorer).restore(); err != nil {
+			slog.Error(
+				"choice session restore subscription failed",
+				slog.Any("token", key),
+				slog.Any("error", err),
+			)
+		}
+
+		return true
+	})
+}
+
+// State 返回当前会话状态
+func (sess *Session) State() SessionState {
+	return SessionState(sess.state.Load())
+}
+
+// States 返回会话状态变化通道, 监管停止后关闭
+func (sess *Session) States() <-chan SessionStateChange {
+	return sess.states
+}
+
+func (sess *Session) Done() <-chan struct{} {
+	return sess.done
+}
+
+// Close 停止会话监管并等待监管协程退出
+func (sess *Session) Close() {
+	sess.cancel()
+	<-sess.done
+}
@@ -0,0 +1,25 @@
+package choice4go
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionBackoff(t *testing.T) {
+	policy := SessionPolicy{
+		MinBackoff: time.Second,
+		MaxBackoff: 10 * time.Second,
+	}
+
+	for attempt, expect := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		4:  8 * time.Second,
+		5:  10 * time.Second,
+		64: 10 * time.Second,
+	} {
+		if wait := policy.backoff(attempt); wait != expect {
+			t.Errorf("attempt[%d] backoff %s, expect %s", attempt, wait, expect)
+		}
+	}
+}
@@ -95,6 +95,51 @@ func (v *EQValue) GetString() string {
 	return v.valueString
 }
 
+// AsInt64 将数值或数字字符串类型的值转换为int64
+func (v *EQValue) AsInt64() (int64, bool) {
+	switch v.valueType {
+	case ValueChar:
+		return int64(v.GetChar()), true
+	case ValueShort:
+		return int64(v.GetShort()), true
+	case ValueUShort:
+		return int64(v.GetUShort()), true
+	case ValueInt:
+		return int64(int32(v.GetInt())), true
+	case ValueUInt:
+		return int64(v.GetUInt()), true
+	case ValueInt64:
+		return v.GetInt64(), true
+	case ValueUInt64:
+		return int64(v.GetUInt64()), true
+	case ValueSingle:
+		return int64(v.GetSingle()), true
+	case ValueDouble:
+		return int64(v.GetDouble()), true
+	case ValueString:
+		result, err := strconv.ParseInt(strings.TrimSpace(v.valueString), 10, 64)
+		return result, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// AsFloat64 将数值或数字字符串类型的值转换为float64
+func (v *EQValue) AsFloat64() (float64, bool) {
+	switch v.valueType {
+	case ValueSingle:
+		return float64(v.GetSingle()), true
+	case ValueDouble:
+		return v.GetDouble(), true
+	case ValueString:
+		result, err := strconv.ParseFloat(strings.TrimSpace(v.valueString), 64)
+		return result, err == nil
+	default:
+		result, ok := v.AsInt64()
+		return float64(result), ok
+	}
+}
+
 func (v *EQValue) GetValue() any {
 	switch v.valueType {
 	case ValueNull:
@@ -133,11 +178,94 @@ func (v *EQValue) GetValue() any {
 	}
 }
 
+var eqDateLayouts = []string{
+	"2006/1/2",
+	"2006-1-2",
+	"20060102",
+	"2006/1/2 15:04:05",
+	"2006-1-2 15:04:05",
+	"20060102150405",
+}
+
+func parseEQDate(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+
+	for _, layout := range eqDateLayouts {
+		if date, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown date format: %q", v)
+}
+
+// hasQuoteTime 指标同时包含DATE及TIME时, 行数据时间可由其解析为行情时间
+func hasQuoteTime(indicators []string) bool {
+	var hasDate, hasTime bool
+
+	for _, name := range indicators {
+		switch {
+		case strings.EqualFold(name, "DATE"):
+			hasDate = true
+		case strings.EqualFold(name, "TIME"):
+			hasTime = true
+		}
+	}
+
+	return hasDate && hasTime
+}
+
+// parseQuoteTime 由行情DATE(yyyymmdd)及TIME(hhmmss或hhmmssfff)指标解析行情时间
+func parseQuoteTime(row Indicator) (time.Time, bool) {
+	dateV, ok := row.Value("DATE")
+	if !ok {
+		return time.Time{}, false
+	}
+
+	date, ok := dateV.AsInt64()
+	if !ok || date <= 0 {
+		return time.Time{}, false
+	}
+
+	var clock, millis int64
+	if timeV, ok := row.Value("TIME"); ok {
+		clock, _ = timeV.AsInt64()
+	}
+
+	if clock > 999999 {
+		millis = clock % 1000
+		clock /= 1000
+	}
+
+	return time.Date(
+		int(date/10000), time.Month(date/100%100), int(date%100),
+		int(clock/10000), int(clock/100%100), int(clock%100),
+		int(millis)*int(time.Millisecond), time.Local,
+	), true
+}
+
 type Indicator struct {
 	Code       string
 	Date       time.Time
 	indicators []string
 	value      []*EQValue
+
+	// 持有原始数据引用, 避免EQValue在行数据使用期间被回收至对象池
+	owner *EQData
+}
+
+func (v Indicator) Indicators() []string {
+	return v.indicators
+}
+
+func (v Indicator) Value(name string) (*EQValue, bool) {
+	for idx, indicator := range v.indicators {
+		if strings.EqualFold(indicator, name) {
+			return v.value[idx], true
+		}
+	}
+
+	return nil, false
 }
 
 func (v Indicator) String() string {
@@ -167,67 +295,181 @@ type EQData struct {
 	indicators []string
 	dateList   []string
 	values     []*EQValue
+
+	// 行情快照数据, 行时间由DATE及TIME指标解析
+	quoteTime bool
+
+	indexOnce    sync.Once
+	dates        []time.Time
+	dateIdx      map[int64]int
+	codeIdx      map[string]int
+	indicatorIdx map[string]int
 }
 
-func (data *EQData) Iter() func(yield func(int, Indicator) bool) {
-	codeSize := len(data.codes)
-	indicatorSize := len(data.indicators)
+//go:generate stringer -type FillMode -linecomment
+type FillMode uint8
 
-	return func(yield func(int, Indicator) bool) {
-		rowIdx := 0
+const (
+	FillNaN      FillMode = iota // 填充NaN
+	FillZero                     // 填充0
+	FillPrevious                 // 沿用前一日期的值
+)
+
+// buildIndex 解析日期并建立证券、指标及日期的下标索引, 仅执行一次
+func (data *EQData) buildIndex() {
+	data.indexOnce.Do(func() {
+		data.dates = make([]time.Time, len(data.dateList))
+		data.dateIdx = make(map[int64]int, len(data.dateList))
 
-		for idxDate, dateStr := range data.dateList {
-			dateV := strings.SplitN(dateStr, "/", 3)
-			yearV, err := strconv.Atoi(dateV[0])
+		for idx, dateStr := range data.dateList {
+			date, err := parseEQDate(dateStr)
 			if err != nil {
 				slog.Error(
 					"parse date failed",
 					slog.Any("error", err),
 					slog.String("date", dateStr),
 				)
+				continue
 			}
-			monthV, err := strconv.Atoi(dateV[1])
-			if err != nil {
-				slog.Error(
-					"parse date failed",
-					slog.Any("error", err),
-					slog.String("date", dateStr),
-				)
+
+			data.dates[idx] = date
+			data.dateIdx[date.UnixNano()] = idx
+		}
+
+		data.codeIdx = make(map[string]int, len(data.codes))
+		for idx, code := range data.codes {
+			data.codeIdx[strings.ToUpper(code)] = idx
+		}
+
+		data.indicatorIdx = make(map[string]int, len(data.indicators))
+		for idx, indicator := range data.indicators {
+			data.indicatorIdx[strings.ToUpper(indicator)] = idx
+		}
+	})
+}
+
+// value 按头文件中的下标公式取值:
+// codeSize * indicatorSize * date + indicatorSize * code + indicator
+func (data *EQData) value(idxDate, idxCode, idxIndicator int) *EQValue {
+	codeSize := len(data.codes)
+	indicatorSize := len(data.indicators)
+
+	return data.values[codeSize*indicatorSize*idxDate+indicatorSize*idxCode+idxIndicator]
+}
+
+// Codes 返回证券代码序列, 与数据共享底层数组, 不可修改
+func (data *EQData) Codes() []string {
+	return data.codes
+}
+
+// Indicators 返回指标名称序列, 与数据共享底层数组, 不可修改
+func (data *EQData) Indicators() []string {
+	return data.indicators
+}
+
+// Dates 返回已解析的日期序列, 结果被缓存, 不可修改
+func (data *EQData) Dates() []time.Time {
+	data.buildIndex()
+
+	return data.dates
+}
+
+// At 随机访问单个数据点, 证券及指标名称不区分大小写
+//
+// date按本地时区截断至自然日匹配, 无需与Dates()中的时间完全一致.
+func (data *EQData) At(code, indicator string, date time.Time) (*EQValue, bool) {
+	data.buildIndex()
+
+	idxCode, exist := data.codeIdx[strings.ToUpper(code)]
+	if !exist {
+		return nil, false
+	}
+
+	idxIndicator, exist := data.indicatorIdx[strings.ToUpper(indicator)]
+	if !exist {
+		return nil, false
+	}
+
+	idxDate, exist := data.dateIdx[date.UnixNano()]
+	if !exist {
+		if idxDate, exist = data.dateIdx[truncateDay(date.In(time.Local)).UnixNano()]; !exist {
+			return nil, false
+		}
+	}
+
+	return data.value(idxDate, idxCode, idxIndicator), true
+}
+
+// Float64Column 提取指标的稠密矩阵, 结果按[日期][证券]排列, 与Dates()及Codes()顺序一致
+//
+// 空值及非数值按fill填充, FillPrevious时首个日期的空值填充NaN.
+func (data *EQData) Float64Column(
+	indicator string, fill FillMode,
+) ([][]float64, bool) {
+	data.buildIndex()
+
+	idxIndicator, exist := data.indicatorIdx[strings.ToUpper(indicator)]
+	if !exist {
+		return nil, false
+	}
+
+	codeSize := len(data.codes)
+	results := make([][]float64, len(data.dateList))
+	buffer := make([]float64, len(data.dateList)*codeSize)
+
+	for idxDate := range data.dateList {
+		row := buffer[idxDate*codeSize : (idxDate+1)*codeSize : (idxDate+1)*codeSize]
+
+		for idxCode := range data.codes {
+			if v, ok := data.value(idxDate, idxCode, idxIndicator).AsFloat64(); ok {
+				row[idxCode] = v
+				continue
 			}
-			dayV, err := strconv.Atoi(dateV[2])
-			if err != nil {
-				slog.Error(
-					"parse date failed",
-					slog.Any("error", err),
-					slog.String("date", dateStr),
-				)
+
+			switch fill {
+			case FillZero:
+				row[idxCode] = 0
+			case FillPrevious:
+				if idxDate > 0 {
+					row[idxCode] = results[idxDate-1][idxCode]
+				} else {
+					row[idxCode] = math.NaN()
+				}
+			default:
+				row[idxCode] = math.NaN()
 			}
+		}
 
-			date := time.Date(
-				yearV, time.Month(monthV), dayV,
-				0, 0, 0, 0, time.Local,
-			)
+		results[idxDate] = row
+	}
 
-			if err != nil {
-				slog.Error(
-					"parse date failed",
-					slog.Any("error", err),
-				)
+	return results, true
+}
 
-				return
-			}
+func (data *EQData) Iter() func(yield func(int, Indicator) bool) {
+	indicatorSize := len(data.indicators)
+
+	return func(yield func(int, Indicator) bool) {
+		rowIdx := 0
 
+		for idxDate, date := range data.Dates() {
 			for idxCode, code := range data.codes {
 				value := Indicator{
 					Code:       code,
 					Date:       date,
 					indicators: data.indicators,
 					value:      make([]*EQValue, indicatorSize),
+					owner:      data,
 				}
 
 				for idxIndicator := range data.indicators {
-					idx := codeSize*indicatorSize*idxDate + indicatorSize*idxCode + idxIndicator
-					value.value[idxIndicator] = data.values[idx]
+					value.value[idxIndicator] = data.value(idxDate, idxCode, idxIndicator)
+				}
+
+				if data.quoteTime {
+					if quoteTime, ok := parseQuoteTime(value); ok {
+						value.Date = quoteTime
+					}
 				}
 
 				if !yield(rowIdx, value) {
@@ -240,9 +482,35 @@ func (data *EQData) Iter() func(yield func(int, Indicator) bool) {
 	}
 }
 
+func releaseEQData(data *EQData) {
+	for _, value := range data.values {
+		valuePool.Put(value)
+	}
+
+	*data = EQData{}
+	dataPool.Put(data)
+}
+
 type Report struct {
 	indicators []string
 	value      []*EQValue
+
+	// 持有原始数据引用, 避免EQValue在行数据使用期间被回收至对象池
+	owner *EQCtrData
+}
+
+func (rpt Report) Columns() []string {
+	return rpt.indicators
+}
+
+func (rpt Report) Value(name string) (*EQValue, bool) {
+	for idx, indicator := range rpt.indicators {
+		if strings.EqualFold(indicator, name) {
+			return rpt.value[idx], true
+		}
+	}
+
+	return nil, false
 }
 
 func (rpt Report) String() string {
@@ -250,12 +518,28 @@ func (rpt Report) String() string {
 	defer bytebufferpool.Put(buff)
 
 	buff.WriteString("Report{")
-	// for _, name := range
+	for idx, name := range rpt.indicators {
+		if idx > 0 {
+			buff.WriteByte(' ')
+		}
+		buff.WriteString(
+			fmt.Sprintf("%s:%+v", name, rpt.value[idx].GetValue()),
+		)
+	}
 	buff.WriteString("}")
 
 	return buff.String()
 }
 
+func releaseEQCtrData(data *EQCtrData) {
+	for _, value := range data.values {
+		valuePool.Put(value)
+	}
+
+	*data = EQCtrData{}
+	ctrDataPool.Put(data)
+}
+
 type EQCtrData struct {
 	row        int
 	column     int
@@ -263,12 +547,21 @@ type EQCtrData struct {
 	values     []*EQValue
 }
 
+func (ctr *EQCtrData) Rows() int {
+	return ctr.row
+}
+
+func (ctr *EQCtrData) Columns() []string {
+	return ctr.indicators
+}
+
 func (ctr *EQCtrData) Iter() func(yield func(int, Report) bool) {
 	return func(yield func(int, Report) bool) {
 		for rowIdx := range ctr.row {
 			value := Report{
 				indicators: ctr.indicators,
 				value:      make([]*EQValue, ctr.column),
+				owner:      ctr,
 			}
 
 			for colIdx := range ctr.column {
@@ -288,5 +581,7 @@ type EQMsg struct {
 	MsgType   eqMsgType
 	RequestID int
 	SerialID  int
+	ErrCode   int
+	Err       error
 	Data      *EQData
 }
@@ -0,0 +1,172 @@
+package choice4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEQDataAccessors(t *testing.T) {
+	// 2个日期 × 2个证券 × 2个指标, 下标: 4*date + 2*code + indicator
+	data := newTestEQData(
+		[]string{"000001.SZ", "600000.SH"}, []string{"OPEN", "CLOSE"},
+		[]string{"2024/1/2", "2024/1/3"},
+		1, 10, 2, 20,
+		3, 30, 4, 40,
+	)
+	data.values[7].valueType = ValueNull
+	data.values[1].valueType = ValueNull
+
+	day1 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+	day2 := time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local)
+
+	if dates := data.Dates(); len(dates) != 2 ||
+		!dates[0].Equal(day1) || !dates[1].Equal(day2) {
+		t.Fatalf("unexpected dates: %v", dates)
+	}
+
+	if v, ok := data.At("600000.sh", "open", day2); !ok || v.GetDouble() != 4 {
+		t.Fatalf("unexpected value at: %+v", v)
+	}
+
+	// 带时分秒或其他时区的时间按本地自然日匹配
+	if v, ok := data.At(
+		"600000.SH", "OPEN", day2.Add(15*time.Hour).In(time.UTC),
+	); !ok || v.GetDouble() != 4 {
+		t.Fatalf("unexpected value at intraday time: %+v", v)
+	}
+
+	if _, ok := data.At("000001.SZ", "OPEN", day2.AddDate(0, 0, 1)); ok {
+		t.Fatal("value found at unknown date")
+	}
+
+	for fill, expect := range map[FillMode][][]float64{
+		FillZero:     {{0, 20}, {30, 0}},
+		FillPrevious: {{math.NaN(), 20}, {30, 20}},
+		FillNaN:      {{math.NaN(), 20}, {30, math.NaN()}},
+	} {
+		column, ok := data.Float64Column("CLOSE", fill)
+		if !ok {
+			t.Fatal("column not found")
+		}
+
+		for idxDate, row := range expect {
+			for idxCode, v := range row {
+				got := column[idxDate][idxCode]
+
+				if got != v && !(math.IsNaN(got) && math.IsNaN(v)) {
+					t.Errorf(
+						"%s column[%d][%d] = %v, expect %v",
+						fill, idxDate, idxCode, got, v,
+					)
+				}
+			}
+		}
+	}
+
+	if _, ok := data.Float64Column("VOLUME", FillNaN); ok {
+		t.Fatal("unknown indicator column found")
+	}
+}
+
+func TestSnapshotQuoteTime(t *testing.T) {
+	cases := []struct {
+		name       string
+		indicators []string
+		values     []float64
+		expect     time.Time
+	}{
+		{
+			"date and time", []string{"NOW", "date", "Time"},
+			[]float64{10.5, 20240102, 93001},
+			time.Date(2024, 1, 2, 9, 30, 1, 0, time.Local),
+		},
+		{
+			"date only", []string{"NOW", "DATE"},
+			[]float64{10.5, 20240102},
+			time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"time only", []string{"NOW", "TIME"},
+			[]float64{10.5, 93001},
+			time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, c := range cases {
+		data := newTestEQData(
+			[]string{"000001.SZ"}, c.indicators, []string{"2024/1/3"}, c.values...,
+		)
+		data.quoteTime = hasQuoteTime(data.indicators)
+
+		for _, row := range data.Iter() {
+			if !row.Date.Equal(c.expect) {
+				t.Errorf("%s: expected row time %v, got %v", c.name, c.expect, row.Date)
+			}
+		}
+	}
+}
+
+func TestEQCtrDataReport(t *testing.T) {
+	data := newTestEQCtrData(
+		[]string{"CODE", "NAME"},
+		[]string{"000001.SZ", "平安银行"},
+		[]string{"600000.SH", "浦发银行"},
+		[]string{"300059.SZ", "东方财富"},
+	)
+
+	if data.Rows() != 3 {
+		t.Fatalf("unexpected rows: %d", data.Rows())
+	}
+
+	if cols := data.Columns(); len(cols) != 2 || cols[0] != "CODE" || cols[1] != "NAME" {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+
+	expected := []string{
+		"Report{CODE:000001.SZ NAME:平安银行}",
+		"Report{CODE:600000.SH NAME:浦发银行}",
+		"Report{CODE:300059.SZ NAME:东方财富}",
+	}
+
+	names := []string{"平安银行", "浦发银行", "东方财富"}
+
+	var count int
+	for idx, rpt := range data.Iter() {
+		count++
+
+		if v := rpt.String(); v != expected[idx] {
+			t.Errorf("row[%d]: expected %s, got %s", idx, expected[idx], v)
+		}
+
+		if cols := rpt.Columns(); len(cols) != 2 || cols[1] != "NAME" {
+			t.Errorf("row[%d]: unexpected columns: %v", idx, cols)
+		}
+
+		if v, ok := rpt.Value("name"); !ok || v.GetString() != names[idx] {
+			t.Errorf("row[%d]: NAME should be found case-insensitively: %v %v", idx, ok, v)
+		}
+
+		if _, ok := rpt.Value("PRICE"); ok {
+			t.Errorf("row[%d]: unknown column should not be found", idx)
+		}
+	}
+
+	if count != data.Rows() {
+		t.Fatalf("iterated %d rows, expected %d", count, data.Rows())
+	}
+
+	count = 0
+	for range data.Iter() {
+		count++
+		break
+	}
+
+	if count != 1 {
+		t.Fatalf("iter not stopped on break: %d", count)
+	}
+
+	if empty := newTestEQCtrData([]string{"CODE"}); empty.Rows() != 0 {
+		t.Fatalf("empty data should have no rows: %d", empty.Rows())
+	}
+}
@@ -0,0 +1,296 @@
+package choice4go
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	subscriptionBufferSize = 1024
+	eventBufferSize        = 64
+)
+
+//go:generate stringer -type SubscriptionEventKind -linecomment
+type SubscriptionEventKind uint8
+
+const (
+	EventError           SubscriptionEventKind = iota // 错误
+	EventReconnecting                                 // 服务器重连
+	EventReconnectFailed                              // 服务器连续重连失败
+	EventLoginFailed                                  // 服务登录验证失败
+	EventAccessDenied                                 // 权限或流量验证失败
+)
+
+// SubscriptionEvent 订阅过程中的状态事件, 如重连及权限验证失败
+type SubscriptionEvent struct {
+	Kind     SubscriptionEventKind
+	SerialID int
+	Code     int
+	Err      error
+}
+
+func subscriptionEventKind(code int) SubscriptionEventKind {
+	switch EQErr(code) {
+	case EQERR_QUOTE_RECONNECT,
+		EQERR_INFO_RECONNECT,
+		EQERR_CHQQUOTE_RECONNECT:
+		return EventReconnecting
+	case EQERR_QUOTE_RECONNECT_FAIL,
+		EQERR_INFO_RECONNECT_FAIL,
+		EQERR_CHQQUOTE_RECONNECT_FAIL:
+		return EventReconnectFailed
+	case EQERR_QUOTE_LOGIN_FAIL,
+		EQERR_INFOQUERY_LOGIN_FAIL,
+		EQERR_INFOSUB_LOGIN_FAIL,
+		EQERR_CHQQUOTE_LOGIN_FAIL:
+		return EventLoginFailed
+	case EQERR_NO_ACCESS,
+		EQERR_ACCESS_EXPIRE,
+		EQERR_NO_LV2_ACCESS,
+		EQERR_LV2_ACCESS_EXPIRE,
+		EQERR_ACCESS_INSUFFICIENCE,
+		EQERR_QUOTE_FLOW_FAIL,
+		EQERR_INFO_FLOW_FAIL,
+		EQERR_CHQQUOTE_ACCESS_FAIL:
+		return EventAccessDenied
+	default:
+		return EventError
+	}
+}
+
+type Quote struct {
+	Indicator
+
+	SerialID int
+}
+
+func convertQuotes(msg *EQMsg) []Quote {
+	if msg.Data == nil {
+		return nil
+	}
+
+	results := make([]Quote, 0, len(msg.Data.codes))
+	for _, row := range msg.Data.Iter() {
+		results = append(results, Quote{Indicator: row, SerialID: msg.SerialID})
+	}
+
+	return results
+}
+
+type QuoteSubscription = Subscription[Quote]
+
+// Subscription 异步订阅, 推送数据经convert转换后通过Updates通道投递
+type Subscription[T any] struct {
+	ins      *Choice
+	serialID int
+	token    uintptr
+	cancelFn func(serialID int) error
+	convert  func(msg *EQMsg) []T
+
+	// resubscribe 以相同参数重新发起订阅, 用于会话重新登录后恢复订阅
+	resubscribe func(token uintptr) (int, error)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	closeLock sync.RWMutex
+	closed    bool
+	updates   chan T
+	events    chan SubscriptionEvent
+
+	err atomic.Pointer[error]
+}
+
+func newSubscription[T any](
+	ctx context.Context, ins *Choice,
+	convert func(msg *EQMsg) []T,
+) *Subscription[T] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sub := &Subscription[T]{
+		ins:     ins,
+		convert: convert,
+		updates: make(chan T, subscriptionBufferSize),
+		events:  make(chan SubscriptionEvent, eventBufferSize),
+	}
+
+	sub.ctx, sub.cancel = context.WithCancel(ctx)
+	sub.token = msgDispatcher.registerSubscriber(sub)
+
+	return sub
+}
+
+// watch 在ctx结束或choice停止时自动取消订阅
+func (sub *Subscription[T]) watch() {
+	rootDone := sub.ins.rootDone()
+
+	go func() {
+		select {
+		case <-sub.ctx.Done():
+		case <-rootDone:
+		}
+
+		if err := sub.Cancel(); err != nil {
+			slog.Error(
+				"choice cancel subscription failed",
+				slog.Int("serial_id", sub.SerialID()),
+				slog.Any("error", err),
+			)
+		}
+	}()
+}
+
+// bind 绑定订阅流水号, 流水号读写均需持有closeLock
+func (sub *Subscription[T]) bind(serialID int) {
+	sub.closeLock.Lock()
+	defer sub.closeLock.Unlock()
+
+	sub.serialID = serialID
+	msgDispatcher.bindSerial(sub.token, serialID)
+}
+
+func (sub *Subscription[T]) SerialID() int {
+	sub.closeLock.RLock()
+	defer sub.closeLock.RUnlock()
+
+	return sub.serialID
+}
+
+// restore 重新发起订阅并绑定新的流水号, 已取消的订阅直接忽略
+func (sub *Subscription[T]) restore() error {
+	sub.closeLock.RLock()
+	closed := sub.closed
+	sub.closeLock.RUnlock()
+
+	if closed || sub.resubscribe == nil {
+		return nil
+	}
+
+	serialID, err := sub.resubscribe(sub.token)
+	if err != nil {
+		return err
+	}
+
+	sub.closeLock.Lock()
+	closed = sub.closed
+	if !closed {
+		slog.Info(
+			"choice subscription restored",
+			slog.Int("old_serial_id", sub.serialID),
+			slog.Int("serial_id", serialID),
+		)
+
+		sub.serialID = serialID
+		msgDispatcher.bindSerial(sub.token, serialID)
+	}
+	sub.closeLock.Unlock()
+
+	// 恢复期间订阅已取消, 退订新订阅; 退订为C调用, 须在释放锁后执行,
+	// 避免与持有读锁的推送回调互相等待
+	if closed && sub.cancelFn != nil {
+		return sub.cancelFn(serialID)
+	}
+
+	return nil
+}
+
+func (sub *Subscription[T]) Updates() <-chan T {
+	return sub.updates
+}
+
+// Events 返回订阅状态事件通道, 订阅取消时关闭
+func (sub *Subscription[T]) Events() <-chan SubscriptionEvent {
+	return sub.events
+}
+
+func (sub *Subscription[T]) Done() <-chan struct{} {
+	return sub.ctx.Done()
+}
+
+// Err 返回订阅过程中收到的最后一个错误
+func (sub *Subscription[T]) Err() error {
+	if err := sub.err.Load(); err != nil {
+		return *err
+	}
+
+	return nil
+}
+
+func (sub *Subscription[T]) Cancel() error {
+	sub.closeLock.Lock()
+
+	if sub.closed {
+		sub.closeLock.Unlock()
+		return nil
+	}
+
+	sub.closed = true
+	sub.cancel()
+
+	serialID := sub.serialID
+
+	sub.ins.subscriptions.Delete(sub.token)
+	msgDispatcher.unregister(sub.token)
+	close(sub.updates)
+	close(sub.events)
+
+	sub.closeLock.Unlock()
+
+	// 退订为C调用, 释放锁后执行
+	if serialID > 0 && sub.cancelFn != nil {
+		return sub.cancelFn(serialID)
+	}
+
+	return nil
+}
+
+func (sub *Subscription[T]) onMessage(msg *EQMsg) {
+	sub.closeLock.RLock()
+	defer sub.closeLock.RUnlock()
+
+	if sub.closed {
+		return
+	}
+
+	if msg.Err != nil {
+		sub.err.Store(&msg.Err)
+
+		event := SubscriptionEvent{
+			Kind:     subscriptionEventKind(msg.ErrCode),
+			SerialID: msg.SerialID,
+			Code:     msg.ErrCode,
+			Err:      msg.Err,
+		}
+
+		slog.Warn(
+			"choice subscription event",
+			slog.String("kind", event.Kind.String()),
+			slog.Int("serial_id", msg.SerialID),
+			slog.Any("error", msg.Err),
+		)
+
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn(
+				"choice subscription event buffer full, event dropped",
+				slog.Int("serial_id", msg.SerialID),
+			)
+		}
+	}
+
+	for _, update := range sub.convert(msg) {
+		select {
+		case sub.updates <- update:
+		default:
+			slog.Warn(
+				"choice subscription buffer full, update dropped",
+				slog.Int("serial_id", msg.SerialID),
+			)
+		}
+	}
+}
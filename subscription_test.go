@@ -0,0 +1,81 @@
+package choice4go
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// lockingCancel 模拟退订期间推送回调获取读锁
+func lockingCancel[T any](sub *Subscription[T], canceled chan<- int) func(int) error {
+	return func(serialID int) error {
+		sub.closeLock.RLock()
+		sub.closeLock.RUnlock()
+
+		canceled <- serialID
+		return nil
+	}
+}
+
+func waitCanceled(t *testing.T, canceled <-chan int, expect int) {
+	t.Helper()
+
+	select {
+	case serialID := <-canceled:
+		if serialID != expect {
+			t.Fatalf("expected cancel serial %d, got %d", expect, serialID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancel func blocked by close lock")
+	}
+}
+
+func TestSubscriptionCancelOutsideLock(t *testing.T) {
+	sub := newSubscription(context.Background(), &Choice{}, convertQuotes)
+
+	canceled := make(chan int, 1)
+	sub.cancelFn = lockingCancel(sub, canceled)
+	sub.bind(7)
+
+	done := make(chan error, 1)
+	go func() { done <- sub.Cancel() }()
+
+	waitCanceled(t, canceled, 7)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sub.Cancel(); err != nil {
+		t.Fatalf("duplicated cancel should be ignored: %v", err)
+	}
+}
+
+func TestSubscriptionRestoreAfterCancel(t *testing.T) {
+	sub := newSubscription(context.Background(), &Choice{}, convertQuotes)
+
+	canceled := make(chan int, 2)
+	sub.cancelFn = lockingCancel(sub, canceled)
+
+	// 重新订阅期间订阅被取消, 新流水号须在释放锁后退订
+	sub.resubscribe = func(uintptr) (int, error) {
+		if err := sub.Cancel(); err != nil {
+			t.Error(err)
+		}
+
+		return 9, nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- sub.restore() }()
+
+	waitCanceled(t, canceled, 9)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if sub.SerialID() == 9 {
+		t.Fatal("canceled subscription should not bind restored serial")
+	}
+}
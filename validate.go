@@ -0,0 +1,194 @@
+package choice4go
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type funType string
+
+const (
+	FunCSD  funType = "CSD"
+	FunCSS  funType = "CSS"
+	FunCSES funType = "CSES"
+)
+
+// cec返回固定的四列数据: INDEX(序号), CODE(传入代码),
+// T/F(正确与否, 适用校验模式), FULLCODES(全代码, 适用补全模式)
+const (
+	cecCodeField     = "CODE"
+	cecValidField    = "T/F"
+	cecFullCodeField = "FULLCODES"
+)
+
+// cfc接口文档未列出返回列, 以下列名为暂定值, 需以实际返回数据核对;
+// 列缺失时返回ErrFieldMissing, 不做猜测
+const (
+	cfcTypeField      = "SECUTYPE"
+	cfcCodeField      = "CODES"
+	cfcIndicatorField = "INDICATORS"
+)
+
+func checkReportFields(fun string, columns []string, names ...string) error {
+	for _, name := range names {
+		if !slices.ContainsFunc(columns, func(v string) bool {
+			return strings.EqualFold(v, name)
+		}) {
+			return fmt.Errorf(
+				"%w: %s result without %s column", ErrFieldMissing, fun, name,
+			)
+		}
+	}
+
+	return nil
+}
+
+func reportString(rpt Report, name string) string {
+	if v, ok := rpt.Value(name); ok && v.Valid() {
+		return valueString(v)
+	}
+
+	return ""
+}
+
+func reportFields(rpt Report) map[string]any {
+	fields := make(map[string]any, len(rpt.indicators))
+
+	for idx, name := range rpt.indicators {
+		fields[name] = rpt.value[idx].GetValue()
+	}
+
+	return fields
+}
+
+func splitList(v string) []string {
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+}
+
+// CodeValidation 证券代码校验或补全结果, 原始字段保存在Fields中
+//
+// 校验模式下Code与Input相同, Valid标记代码是否正确;
+// 补全模式下Code为补全后缀后的代码, 同一Input可能对应多条结果,
+// 无法补全时Code为空且Valid为false.
+type CodeValidation struct {
+	Input  string
+	Code   string
+	Valid  bool
+	Fields map[string]any
+}
+
+func cecMode(options Option) cecReturnType {
+	if v, ok := lookupOption(options, "ReturnType"); ok && v == "1" {
+		return CecComplete
+	}
+
+	return CecVerify
+}
+
+func parseValidFlag(v *EQValue) bool {
+	switch v.GetType() {
+	case ValueBool:
+		return v.GetBool()
+	case ValueString:
+		switch strings.ToUpper(strings.TrimSpace(v.GetString())) {
+		case "T", "TRUE", "1":
+			return true
+		}
+
+		return false
+	default:
+		flag, _ := v.AsInt64()
+		return flag != 0
+	}
+}
+
+func newCodeValidations(
+	data *EQCtrData, mode cecReturnType,
+) ([]CodeValidation, error) {
+	resultField := cecValidField
+	if mode == CecComplete {
+		resultField = cecFullCodeField
+	}
+
+	if err := checkReportFields(
+		"cec", data.Columns(), cecCodeField, resultField,
+	); err != nil {
+		return nil, err
+	}
+
+	results := make([]CodeValidation, 0, data.Rows())
+
+	for _, rpt := range data.Iter() {
+		result := CodeValidation{
+			Input:  reportString(rpt, cecCodeField),
+			Fields: reportFields(rpt),
+		}
+
+		if mode != CecComplete {
+			result.Code = result.Input
+
+			if v, ok := rpt.Value(cecValidField); ok && v.Valid() {
+				result.Valid = parseValidFlag(v)
+			}
+
+			results = append(results, result)
+			continue
+		}
+
+		fullCodes := slices.DeleteFunc(
+			splitList(reportString(rpt, cecFullCodeField)),
+			func(v string) bool {
+				v = strings.TrimSpace(v)
+				return v == "" || strings.EqualFold(v, "None")
+			},
+		)
+
+		if len(fullCodes) == 0 {
+			results = append(results, result)
+			continue
+		}
+
+		for _, code := range fullCodes {
+			completed := result
+			completed.Code = strings.TrimSpace(code)
+			completed.Valid = true
+
+			results = append(results, completed)
+		}
+	}
+
+	return results, nil
+}
+
+// IndicatorValidation 按证券品种分组的证券与指标校验结果, 原始字段保存在Fields中
+type IndicatorValidation struct {
+	SecuType   string
+	Codes      []string
+	Indicators []string
+	Fields     map[string]any
+}
+
+func newIndicatorValidations(data *EQCtrData) ([]IndicatorValidation, error) {
+	if err := checkReportFields(
+		"cfc", data.Columns(),
+		cfcTypeField, cfcCodeField, cfcIndicatorField,
+	); err != nil {
+		return nil, err
+	}
+
+	results := make([]IndicatorValidation, 0, data.Rows())
+
+	for _, rpt := range data.Iter() {
+		results = append(results, IndicatorValidation{
+			SecuType:   reportString(rpt, cfcTypeField),
+			Codes:      splitList(reportString(rpt, cfcCodeField)),
+			Indicators: splitList(reportString(rpt, cfcIndicatorField)),
+			Fields:     reportFields(rpt),
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,88 @@
+package choice4go
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestEQCtrData(columns []string, rows ...[]string) *EQCtrData {
+	data := &EQCtrData{
+		row:        len(rows),
+		column:     len(columns),
+		indicators: columns,
+	}
+
+	for _, row := range rows {
+		for _, v := range row {
+			data.values = append(data.values, &EQValue{
+				valueType: ValueString, valueString: v,
+			})
+		}
+	}
+
+	return data
+}
+
+func TestCodeValidationsVerify(t *testing.T) {
+	data := newTestEQCtrData(
+		[]string{"INDEX", "CODE", "T/F", "FULLCODES"},
+		[]string{"1", "000001.SZ", "T", ""},
+		[]string{"2", "000000.TEST", "F", ""},
+	)
+
+	results, err := newCodeValidations(data, CecVerify)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 ||
+		!results[0].Valid || results[0].Code != "000001.SZ" ||
+		results[1].Valid || results[1].Input != "000000.TEST" {
+		t.Fatalf("unexpected verify results: %+v", results)
+	}
+}
+
+func TestCodeValidationsComplete(t *testing.T) {
+	data := newTestEQCtrData(
+		[]string{"INDEX", "CODE", "T/F", "FULLCODES"},
+		[]string{"1", "000001", "", "000001.SZ,000001.SH"},
+		[]string{"2", "000000", "", "None"},
+	)
+
+	results, err := newCodeValidations(data, CecComplete)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 ||
+		results[0].Code != "000001.SZ" || results[1].Code != "000001.SH" ||
+		!results[0].Valid || !results[1].Valid ||
+		results[2].Input != "000000" || results[2].Valid {
+		t.Fatalf("unexpected complete results: %+v", results)
+	}
+}
+
+func TestCodeValidationsMissingField(t *testing.T) {
+	data := newTestEQCtrData(
+		[]string{"INDEX", "CODE"},
+		[]string{"1", "000001.SZ"},
+	)
+
+	if _, err := newCodeValidations(data, CecVerify); !errors.Is(err, ErrFieldMissing) {
+		t.Fatalf("expected ErrFieldMissing, got %v", err)
+	}
+
+	if _, err := newIndicatorValidations(data); !errors.Is(err, ErrFieldMissing) {
+		t.Fatalf("expected ErrFieldMissing, got %v", err)
+	}
+}
+
+func TestCecMode(t *testing.T) {
+	if mode := cecMode(NewCecOptions().ReturnType(CecComplete)); mode != CecComplete {
+		t.Fatalf("expected complete mode, got %v", mode)
+	}
+
+	if mode := cecMode(NewCecOptions()); mode != CecVerify {
+		t.Fatalf("expected verify mode, got %v", mode)
+	}
+}